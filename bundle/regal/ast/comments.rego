@@ -45,16 +45,61 @@ annotations := array.concat(
 # METADATA
 # description: |
 #   map of all ignore directive comments, like ("# regal ignore:line-length")
-#   found in input AST, indexed by the row they're at
+#   found in input AST, indexed by the row they're at. directives may be followed
+#   by a reason, and a date after which they expire, like (reason="legacy" until=2030-01-31),
+#   and expired directives are not included
 ignore_directives[row] := rules if {
 	some comment in comments_decoded
 
 	contains(comment.text, "regal ignore:")
+	not _expired(comment.text)
 
 	loc := util.to_location_object(comment.location)
 	row := loc.row + 1
 
-	rules := regex.split(`,\s*`, trim_space(regex.replace(comment.text, `^.*regal ignore:\s*(\S+)`, "$1")))
+	rules := _directive_rules("ignore", comment.text)
+}
+
+# METADATA
+# description: |
+#   set of all block ignore directives, i.e. the rules to ignore from the row of an
+#   ignore-start comment, like ("# regal ignore-start:line-length"), up until the row
+#   of the next ignore-end comment ("# regal ignore-end"), or else the end of the file
+ignore_ranges contains {"start": start, "end": end, "rules": rules} if {
+	some comment in comments_decoded
+
+	contains(comment.text, "regal ignore-start:")
+	not _expired(comment.text)
+
+	start := util.to_location_object(comment.location).row
+	end := _ignore_range_end(start)
+
+	rules := _directive_rules("ignore-start", comment.text)
+}
+
+_ignore_range_end(start) := min(ends) if {
+	ends := {row |
+		some comment in comments_decoded
+
+		regex.match(`^\s*regal ignore-end\b`, comment.text)
+
+		row := util.to_location_object(comment.location).row
+		row > start
+	}
+	count(ends) > 0
+} else := count(input.regal.file.lines)
+
+_directive_rules(directive, text) := regex.split(`\s*,\s*`, regex.find_all_string_submatch_n(
+	$`regal {directive}:\s*([\w-]+(?:\s*,\s*[\w-]+)*)`,
+	text,
+	1,
+)[0][1])
+
+# directives expire after the date set by until, i.e. they still apply on that date
+_expired(text) if {
+	until := regex.find_all_string_submatch_n(`\buntil=(\d{4}-\d{2}-\d{2})\b`, text, 1)[0][1]
+
+	until < time.format([time.now_ns(), "UTC", "2006-01-02"])
 }
 
 # METADATA
@@ -32,9 +32,25 @@ merged_config := data.internal.combined_config
 default rules := {}
 
 # METADATA
-# description: the merged (default and user) configuration for rules
+# description: |
+#   the merged (default and user) configuration for rules, or if any overrides
+#   apply to the file linted, the configuration for rules in effect for that file,
+#   with any inline configuration from comments in the file merged on top
 # scope: document
-rules := merged_config.rules
+rules := _with_inline_config(file_rules) if {
+	file_rules := input.regal.config.rules
+} else := _with_inline_config(merged_config.rules)
+
+_with_inline_config(base) := base if {
+	count(inline_config) == 0
+} else := object.union(base, {category: category_config |
+	some category, category_rules in base
+
+	category_config := {title: conf |
+		some title, conf in inline_config
+		category_rules[title]
+	}
+})
 
 # METADATA
 # description: the resolved capabilities sourced from Regal and user configuration
@@ -48,6 +64,8 @@ default _params := {
 	"enable_all": false,
 	"disable_category": [],
 	"enable_category": [],
+	"disable_tag": [],
+	"enable_tag": [],
 	"disable": [],
 	"enable": [],
 }
@@ -76,6 +94,19 @@ ignored_rule(category, title) if {
 	not _force_enabled(_params, category, title)
 }
 
+# METADATA
+# description: |
+#   answers whether a rule is enabled by any of the overrides in the configuration,
+#   i.e. for some files, unless the rule is disabled entirely by flags
+enabled_by_override(category, title) if {
+	not _force_disabled(_params, category, title)
+
+	some override in merged_config.overrides
+	level := override.rules[category][title].level
+
+	not level in {"", "ignore"}
+}
+
 # METADATA
 # description: returns the level set for rule, based on configuration and possibly overrides
 level_for_rule(category, title) := "ignore" if {
@@ -91,6 +122,7 @@ _force_disabled(params, _, title) if title in params.disable
 _force_disabled(params, category, title) if {
 	params.disable_all == true
 	not category in params.enable_category
+	not _tagged(category, title, params.enable_tag)
 	not title in params.enable
 }
 
@@ -99,11 +131,17 @@ _force_disabled(params, category, title) if {
 	not title in params.enable
 }
 
+_force_disabled(params, category, title) if {
+	_tagged(category, title, params.disable_tag)
+	not title in params.enable
+}
+
 _force_enabled(params, _, title) if title in params.enable
 
 _force_enabled(params, category, title) if {
 	params.enable_all == true
 	not category in params.disable_category
+	not _tagged(category, title, params.disable_tag)
 	not title in params.disable
 }
 
@@ -111,3 +149,22 @@ _force_enabled(params, category, title) if {
 	category in params.enable_category
 	not title in params.disable
 }
+
+_force_enabled(params, category, title) if {
+	_tagged(category, title, params.enable_tag)
+	not title in params.disable
+}
+
+# METADATA
+# description: |
+#   the tags declared by each rule in the custom section of its package metadata,
+#   indexed by category and title, as provided by the linter
+# scope: document
+default rule_tags := {}
+
+rule_tags := data.internal.rule_tags
+
+_tagged(category, title, tags) if {
+	some tag in rule_tags[category][title]
+	tag in tags
+}
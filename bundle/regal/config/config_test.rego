@@ -10,6 +10,8 @@ params(override) := object.union(
 		"enable_all": false,
 		"enable_category": [],
 		"enable": [],
+		"disable_tag": [],
+		"enable_tag": [],
 		"ignore_files": [],
 	},
 	override,
@@ -52,6 +54,24 @@ test_config[name] if {
 	l == want_level
 }
 
+test_config_tags[name] if {
+	some name, [override, want_level] in {
+		"disable_tag": [{"disable_tag": ["slow"]}, "ignore"],
+		"disable_tag_other": [{"disable_tag": ["fast"]}, "warning"],
+		"disable_tag_with_rule_override": [{"disable_tag": ["slow"], "enable": ["test-case"]}, "error"],
+		"disable_all_with_tag_override": [{"disable_all": true, "enable_tag": ["slow"]}, "error"],
+		"enable_tag": [{"enable_tag": ["slow"]}, "error"],
+		"enable_tag_with_rule_override": [{"enable_tag": ["slow"], "disable": ["test-case"]}, "ignore"],
+		"enable_all_with_tag_override": [{"enable_all": true, "disable_tag": ["slow"]}, "ignore"],
+	}
+
+	l := config.level_for_rule("test", "test-case") with data.eval.params as params(override)
+		with data.internal.rule_tags as {"test": {"test-case": ["slow", "security"]}}
+		with config.rules as {"test": {"test-case": {"level": "warning"}}}
+
+	l == want_level
+}
+
 test_all_rules_are_in_provided_configuration if {
 	missing_config := {title |
 		some category, title
@@ -77,3 +97,36 @@ test_path_prefix if {
 	config.path_prefix == ""
 	config.path_prefix == "foo" with data.internal.path_prefix as "foo"
 }
+
+test_rules_from_input_when_overrides_apply if {
+	rules := config.rules with data.internal.combined_config as {"rules": {"test": {"test-case": {"level": "error"}}}}
+		with input as {"regal": {"config": {"rules": {"test": {"test-case": {"level": "ignore"}}}}}}
+
+	rules == {"test": {"test-case": {"level": "ignore"}}}
+}
+
+test_rules_from_config_when_no_overrides_apply if {
+	rules := config.rules with data.internal.combined_config as {"rules": {"test": {"test-case": {"level": "error"}}}}
+		with input as {"regal": {}}
+
+	rules == {"test": {"test-case": {"level": "error"}}}
+}
+
+_overridden_config := {
+	"rules": {"test": {"test-case": {"level": "ignore"}}},
+	"overrides": [{"files": ["tests/**"], "rules": {"test": {"test-case": {"level": "warning"}}}}],
+}
+
+test_enabled_by_override if {
+	p := params({})
+
+	config.enabled_by_override("test", "test-case") with data.internal.combined_config as _overridden_config
+		with data.eval.params as p
+}
+
+test_not_enabled_by_override_when_disabled_by_flag if {
+	p := params({"disable": ["test-case"]})
+
+	not config.enabled_by_override("test", "test-case") with data.internal.combined_config as _overridden_config
+		with data.eval.params as p
+}
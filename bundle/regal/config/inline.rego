@@ -0,0 +1,54 @@
+package regal.config
+
+# METADATA
+# description: |
+#   set of rules disabled for the whole file by disable-file directives,
+#   like ("# regal disable-file: rule-length, line-length")
+disabled_rules contains title if {
+	some comment in input.comments
+
+	rules := regex.find_all_string_submatch_n(
+		`^\s*regal disable-file:\s*(.+)$`,
+		base64.decode(comment.text),
+		1,
+	)[0][1]
+
+	some title in regex.split(`\s*,\s*`, trim_space(rules))
+}
+
+# METADATA
+# description: |
+#   map of rule configuration set for the file by inline config comments, like
+#   ("# regal config: line-length.max-line-length=160"), keyed by rule. values
+#   are parsed as JSON if valid, and used as strings otherwise. rules disabled
+#   by disable-file directives have their level set to "ignore"
+inline_config := object.union_n(array.concat(
+	[{title: {attribute: _inline_value(value)}} |
+		some comment in input.comments
+
+		settings := regex.find_all_string_submatch_n(
+			`^\s*regal config:\s*(.+)$`,
+			base64.decode(comment.text),
+			1,
+		)[0][1]
+
+		some match in regex.find_all_string_submatch_n(
+			`([\w-]+)\.([\w-]+)\s*=\s*("[^"]*"|\[[^\]]*\]|[^,\s]+)`,
+			settings,
+			-1,
+		)
+
+		[_, title, attribute, value] := match
+	],
+	[{title: {"level": "ignore"}} | some title in disabled_rules],
+))
+
+_inline_value(value) := json.unmarshal(value) if {
+	json.is_valid(value)
+} else := value
+
+# METADATA
+# description: |
+#   answers whether a rule is disabled for the file by inline configuration, which
+#   like ignore directives can't be overridden by other means, like flags
+disabled_in_file(title) if inline_config[title].level == "ignore"
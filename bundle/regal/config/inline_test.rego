@@ -0,0 +1,69 @@
+package regal.config_test
+
+import data.regal.config
+
+test_disabled_rules_from_disable_file_directives if {
+	module := regal.parse_module("p.rego", `package p
+
+# regal disable-file: rule-length, line-length
+# regal disable-file:opa-fmt
+`)
+
+	config.disabled_rules with input as module == {"rule-length", "line-length", "opa-fmt"}
+}
+
+test_inline_config_from_config_comments if {
+	module := regal.parse_module("p.rego", `package p
+
+# regal config: line-length.max-line-length=160, line-length.level=warning
+# regal config: rule-length.except-empty-body=true rule-length.max-rule-length=20
+# regal config: prefer-snake-case.ignore-pattern="^camel"
+# regal disable-file: opa-fmt
+`)
+
+	config.inline_config with input as module == {
+		"line-length": {"max-line-length": 160, "level": "warning"},
+		"rule-length": {"except-empty-body": true, "max-rule-length": 20},
+		"prefer-snake-case": {"ignore-pattern": "^camel"},
+		"opa-fmt": {"level": "ignore"},
+	}
+}
+
+test_inline_config_unquoted_string_value if {
+	module := regal.parse_module("p.rego", "package p\n\n# regal config: line-length.level=warning\n")
+
+	config.inline_config["line-length"].level == "warning" with input as module
+}
+
+test_inline_config_merged_with_rules if {
+	module := regal.parse_module("p.rego", `package p
+
+# regal config: line-length.max-line-length=160, unknown-rule.level=error
+`)
+
+	rules := config.rules with input as module with config.merged_config as {"rules": {"style": {
+		"line-length": {"level": "error", "max-line-length": 120},
+		"opa-fmt": {"level": "error"},
+	}}}
+
+	rules == {"style": {
+		"line-length": {"level": "error", "max-line-length": 160},
+		"opa-fmt": {"level": "error"},
+	}}
+}
+
+test_inline_config_merged_with_override_rules if {
+	module := object.union(
+		regal.parse_module("p.rego", "package p\n\n# regal disable-file: line-length\n"),
+		{"regal": {"config": {"rules": {"style": {"line-length": {"level": "warning"}}}}}},
+	)
+
+	config.rules with input as module == {"style": {"line-length": {"level": "ignore"}}}
+}
+
+test_disabled_in_file if {
+	module := regal.parse_module("p.rego", "package p\n\n# regal disable-file: line-length\n")
+
+	config.disabled_in_file("line-length") with input as module
+	not config.disabled_in_file("opa-fmt") with input as module
+}
@@ -117,6 +117,56 @@ actions contains action if {
 	}
 }
 
+# METADATA
+# description: |
+#   Code action to ignore the rules of all diagnostics in a selection spanning multiple
+#   lines, by wrapping the selected lines in a block ignore directive
+actions contains action if {
+	"quickfix" in only
+
+	start := input.params.range.start.line
+	end := _last_selected_line(input.params.range)
+	end > start
+
+	diagnostics := [diag |
+		some diag in input.params.context.diagnostics
+
+		diag.range.start.line >= start
+		diag.range.start.line <= end
+	]
+	count(diagnostics) > 0
+
+	titles := concat(",", sort({diag.code | some diag in diagnostics}))
+	title := "Ignore these rules in selected lines"
+
+	action := {
+		"title": title,
+		"kind": "quickfix",
+		"diagnostics": diagnostics,
+		"isPreferred": false,
+		"edit": {"documentChanges": [{
+			"textDocument": {"uri": input.params.textDocument.uri, "version": null},
+			"edits": _ignore_block_edits(start, end, titles),
+		}]},
+	}
+}
+
+# a selection ending at the start of a line doesn't include that line
+_last_selected_line(range) := range.end.line - 1 if {
+	range.end.character == 0
+	range.end.line > range.start.line
+} else := range.end.line
+
+_ignore_block_edits(start, end, titles) := [
+	{"range": _line_start(start), "newText": $"# regal ignore-start:{titles}\n"},
+	{"range": _line_start(end + 1), "newText": "# regal ignore-end\n"},
+]
+
+_line_start(line) := {
+	"start": {"line": line, "character": 0},
+	"end": {"line": line, "character": 0},
+}
+
 # METADATA
 # description: All code actions for fixing reported diagnostics
 rules := {
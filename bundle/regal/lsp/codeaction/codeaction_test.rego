@@ -124,6 +124,59 @@ test_code_actions_specific_to_vscode_reported_on_client_match if {
 	}
 }
 
+test_code_action_ignore_rules_in_selected_lines if {
+	diagnostics := [
+		_diagnostics["opa-fmt"],
+		_diagnostics["use-assignment-operator"],
+		{
+			"code": "prefer-snake-case",
+			"message": "Prefer snake_case for names",
+			"range": {"start": {"line": 4, "character": 1}, "end": {"line": 4, "character": 10}},
+		},
+	]
+
+	r := codeaction.actions with input as {
+		"regal": {"client": {"identifier": clients.generic}},
+		"params": {
+			"textDocument": {"uri": "file:///workspace/policy.rego"},
+			"range": {"start": {"line": 2, "character": 0}, "end": {"line": 5, "character": 0}},
+			"context": {"diagnostics": diagnostics},
+		},
+	}
+
+	action := [action | some action in r; action.title == "Ignore these rules in selected lines"][0]
+
+	action.diagnostics == array.slice(diagnostics, 1, 3)
+	action.edit.documentChanges == [{
+		"textDocument": {"uri": "file:///workspace/policy.rego", "version": null},
+		"edits": [
+			{
+				"range": {"start": {"line": 2, "character": 0}, "end": {"line": 2, "character": 0}},
+				"newText": "# regal ignore-start:prefer-snake-case,use-assignment-operator\n",
+			},
+			{
+				"range": {"start": {"line": 5, "character": 0}, "end": {"line": 5, "character": 0}},
+				"newText": "# regal ignore-end\n",
+			},
+		],
+	}]
+}
+
+test_code_action_ignore_rules_not_offered_for_single_line if {
+	r := codeaction.actions with input as {
+		"regal": {"client": {"identifier": clients.generic}},
+		"params": {
+			"textDocument": {"uri": "file:///workspace/policy.rego"},
+			"range": {"start": {"line": 2, "character": 0}, "end": {"line": 3, "character": 0}},
+			"context": {"diagnostics": [_diagnostics["use-assignment-operator"]]},
+		},
+	}
+
+	every action in r {
+		action.title != "Ignore these rules in selected lines"
+	}
+}
+
 test_code_actions_only_quickfix if {
 	diagnostic := _diagnostics["use-assignment-operator"]
 
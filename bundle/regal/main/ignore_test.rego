@@ -0,0 +1,179 @@
+package regal.main_test
+
+import data.regal.config
+import data.regal.main
+
+test_ignore_block_directive if {
+	policy := `package p
+
+# regal ignore-start:prefer-snake-case,use-assignment-operator
+camelCase := "yes"
+
+default otherCamelCase = "yes"
+# regal ignore-end
+
+lastCamelCase := "yes"
+`
+	report := main.report with input as regal.parse_module("p.rego", policy) with config.rules as {"style": {
+		"prefer-snake-case": {"level": "error"},
+		"use-assignment-operator": {"level": "error"},
+	}}
+
+	{[violation.title, violation.location.row] | some violation in report} == {["prefer-snake-case", 9]}
+}
+
+test_ignore_block_directive_without_end_applies_to_rest_of_file if {
+	policy := `package p
+
+# regal ignore-start:prefer-snake-case
+camelCase := "yes"
+
+otherCamelCase := "yes"
+`
+	report := main.report with input as regal.parse_module("p.rego", policy)
+		with config.rules as {"style": {"prefer-snake-case": {"level": "error"}}}
+
+	count(report) == 0
+}
+
+test_ignore_block_directive_collected if {
+	module := regal.parse_module("p.rego", `package p
+
+# regal ignore-start:unresolved-import
+import data.a
+# regal ignore-end
+`)
+
+	lint := main.lint with input as object.union(module, {"regal": {"operations": ["lint"]}})
+
+	lint.ignore_ranges == {"p.rego": {{"start": 3, "end": 5, "rules": ["unresolved-import"]}}}
+}
+
+test_ignore_block_directive_enforced_in_aggregate_rule if {
+	report := main.aggregate_report with input as {
+		"aggregates_internal": {"imports/unresolved-import": []},
+		"regal": {"file": {"name": "p.rego"}},
+		"ignore_directives": {},
+		"ignore_ranges": {"p.rego": [{"start": 3, "end": 8, "rules": ["unresolved-import"]}]},
+	}
+		with config.rules as {"imports": {"unresolved-import": {"level": "error"}}}
+		with data.regal.rules.imports["unresolved-import"].aggregate_report as {{
+			"category": "imports",
+			"level": "error",
+			"location": {"col": 1, "file": "p.rego", "row": 6, "text": "import data.provider.parameters"},
+			"title": "unresolved-import",
+		}}
+
+	count(report) == 0
+}
+
+test_ignore_directive_with_reason_and_until if {
+	policy := `package p
+
+# regal ignore:prefer-snake-case,use-assignment-operator reason="legacy API" until=2030-01-31
+default camelCase = "yes"
+`
+	report := main.report with input as regal.parse_module("p.rego", policy) with config.rules as {"style": {
+		"prefer-snake-case": {"level": "error"},
+		"use-assignment-operator": {"level": "error"},
+	}}
+		with time.now_ns as time.parse_ns("2006-01-02", "2030-01-31")
+
+	count(report) == 0
+}
+
+test_expired_ignore_directive_suppresses_nothing if {
+	policy := `package p
+
+# regal ignore:prefer-snake-case until=2030-01-31
+camelCase := "yes"
+
+# regal ignore-start:prefer-snake-case until=2030-01-31
+otherCamelCase := "yes"
+# regal ignore-end
+`
+	report := main.report with input as regal.parse_module("p.rego", policy)
+		with config.rules as {"style": {"prefer-snake-case": {"level": "error"}}}
+		with time.now_ns as time.parse_ns("2006-01-02", "2030-02-01")
+
+	count(report) == 2
+}
+
+test_used_ignore_directives_collected if {
+	module := regal.parse_module("p.rego", `package p
+
+# regal ignore:prefer-snake-case,use-assignment-operator
+camelCase := "yes"
+
+# regal ignore:prefer-snake-case
+snake_case := "yes"
+
+# regal ignore-start:prefer-snake-case,use-assignment-operator
+otherCamelCase := "yes"
+# regal ignore-end
+`)
+
+	lint := main.lint with input as object.union(module, {"regal": {"operations": ["lint"]}})
+		with config.rules as {"style": {
+			"prefer-snake-case": {"level": "error"},
+			"use-assignment-operator": {"level": "error"},
+		}}
+
+	lint.used_ignore_directives == {"p.rego": {4: {"prefer-snake-case"}}}
+	lint.used_ignore_ranges == {"p.rego": {9: {"prefer-snake-case"}}}
+}
+
+test_used_ignore_directives_collected_in_aggregate_rule if {
+	lint := main.lint with input as {
+		"aggregates_internal": {"imports/unresolved-import": []},
+		"regal": {"file": {"name": "__aggregate_report__"}, "operations": ["aggregate"]},
+		"ignore_directives": {
+			"p.rego": {"6": ["unresolved-import"]},
+			"q.rego": {"3": ["unresolved-import"]},
+		},
+		"ignore_ranges": {"q.rego": [{"start": 5, "end": 7, "rules": ["unresolved-import"]}]},
+	}
+		with config.rules as {"imports": {"unresolved-import": {"level": "error"}}}
+		with data.regal.rules.imports["unresolved-import"].aggregate_report as {
+			{
+				"category": "imports",
+				"level": "error",
+				"location": {"col": 1, "file": "p.rego", "row": 6, "text": "import data.provider.parameters"},
+				"title": "unresolved-import",
+			},
+			{
+				"category": "imports",
+				"level": "error",
+				"location": {"col": 1, "file": "q.rego", "row": 6, "text": "import data.provider.other"},
+				"title": "unresolved-import",
+			},
+		}
+
+	lint.aggregate.violations == set()
+	lint.aggregate.used_ignore_directives == {"p.rego": {6: {"unresolved-import"}}}
+	lint.aggregate.used_ignore_ranges == {"q.rego": {5: {"unresolved-import"}}}
+}
+
+test_evaluated_rules_exclude_rules_disabled_for_file if {
+	policy := `package p
+
+# regal disable-file:use-assignment-operator
+x := 1
+`
+	module := regal.parse_module("p/p.rego", policy)
+
+	# selecting a rule for evaluation doesn't change the rules reported as evaluated
+	mock_input := object.union(module, {"regal": {"operations": ["lint"], "rule": ""}})
+	cfg := {"style": {
+		"prefer-snake-case": {"level": "error"},
+		"use-assignment-operator": {"level": "error"},
+		"line-length": {"level": "error", "ignore": {"files": ["p/*"]}},
+		"opa-fmt": {"level": "ignore"},
+	}}
+
+	result := main.lint with input as mock_input
+		with config.rules as cfg
+		with data.internal.custom_rules as ["custom/my-rule"]
+
+	result.evaluated_rules == {"p/p.rego": {"custom/my-rule", "style/prefer-snake-case"}}
+}
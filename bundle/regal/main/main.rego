@@ -20,6 +20,10 @@ lint.notices contains _grouped_notices[_][_][_] if "lint" in input.regal.operati
 # description: map of all ignore directives encountered when linting
 lint.ignore_directives[input.regal.file.name] := ast.ignore_directives if "lint" in input.regal.operations
 
+# METADATA
+# description: map of all block ignore directives encountered when linting
+lint.ignore_ranges[input.regal.file.name] := ast.ignore_ranges if "lint" in input.regal.operations
+
 # METADATA
 # description: all violations from non-aggregate rules
 lint.violations := report if "lint" in input.regal.operations
@@ -37,8 +41,9 @@ _file_name_relative_to_root(filename, root) := trim_prefix(filename, concat("",
 
 # METADATA
 # description: |
-#   set of all rules not disabled by configuration
+#   set of all rules not disabled by configuration, including rules enabled only for some files by overrides
 #   note that this only accounts for rules disabled entirely, not for specific files, or via flags
+# scope: document
 enabled_rules[category][title] if {
 	some category, title
 	config.rules[category][title]
@@ -46,6 +51,13 @@ enabled_rules[category][title] if {
 	not config.ignored_rule(category, title)
 }
 
+enabled_rules[category][title] if {
+	some category, title
+	config.rules[category][title]
+
+	config.enabled_by_override(category, title)
+}
+
 _rules_to_run[category] contains title if {
 	relative_filename := _file_name_relative_to_root(input.regal.file.name, config.path_prefix)
 	not config.ignored_globally(relative_filename)
@@ -53,8 +65,74 @@ _rules_to_run[category] contains title if {
 	some category, title
 	config.rules[category][title]
 
+	_selected(category, title)
+
+	not config.ignored_rule(category, title)
+	not config.excluded_file(category, title, relative_filename)
+}
+
+# METADATA
+# description: |
+#   map of the rules evaluated for the file linted, as category/title, keyed by file name,
+#   regardless of whether a single rule was selected for evaluation via input.regal.rule
+lint.evaluated_rules[input.regal.file.name] := _evaluated_rules if "lint" in input.regal.operations
+
+# bundled rules, and custom rules found in config
+_evaluated_rules contains concat("/", [category, title]) if {
+	relative_filename := _file_name_relative_to_root(input.regal.file.name, config.path_prefix)
+	not config.ignored_globally(relative_filename)
+
+	some category, title
+	config.rules[category][title]
+
+	_evaluated_in_file(category, title, relative_filename)
+	not _has_notices(category, title)
+}
+
+# custom rules, as provided by the linter
+_evaluated_rules contains rule if {
+	relative_filename := _file_name_relative_to_root(input.regal.file.name, config.path_prefix)
+	not config.ignored_globally(relative_filename)
+
+	some rule in data.internal.custom_rules
+	[category, title] := split(rule, "/")
+
+	_evaluated_in_file(category, title, relative_filename)
+}
+
+_evaluated_in_file(category, title, relative_filename) if {
 	not config.ignored_rule(category, title)
 	not config.excluded_file(category, title, relative_filename)
+	not config.disabled_in_file(title)
+}
+
+_has_notices(category, title) if count(data.regal.rules[category][title].notices) > 0
+
+# when input.regal.rule is set to a category/title, only that rule is evaluated, which is
+# used by the linter to find rules exceeding the evaluation time budget for a file
+_selected(_, _) if not input.regal.rule
+_selected(category, title) if input.regal.rule == concat("/", [category, title])
+
+# as custom rules aren't enumerated from config, the selection is made here, before
+# evaluating them, as evaluating the rules not selected is what's to be avoided
+_custom_report[category][title] := data.custom.regal.rules[category][title].report if {
+	not input.regal.rule
+
+	some category, title
+}
+
+_custom_report[category][title] := data.custom.regal.rules[category][title].report if {
+	[category, title] := split(input.regal.rule, "/")
+}
+
+_custom_aggregate[category][title] := data.custom.regal.rules[category][title].aggregate if {
+	not input.regal.rule
+
+	some category, title
+}
+
+_custom_aggregate[category][title] := data.custom.regal.rules[category][title].aggregate if {
+	[category, title] := split(input.regal.rule, "/")
 }
 
 _grouped_notices[category][title] contains notice if {
@@ -89,8 +167,32 @@ report contains violation if {
 	}
 }
 
-# Check bundled rules
+# Check bundled and custom rules, skipping violations suppressed by ignore directives
 report contains violation if {
+	some violation in _violations
+
+	not _ignored(violation, ast.ignore_directives)
+	not _ignored_in_range(violation, ast.ignore_ranges)
+}
+
+# METADATA
+# description: |
+#   map of the ignore directives that suppressed violations when linting, in the same
+#   format as ignore_directives, used to find directives that no longer suppress anything
+lint.used_ignore_directives[input.regal.file.name] := _used_ignore_directives(_violations, ast.ignore_directives) if {
+	"lint" in input.regal.operations
+}
+
+# METADATA
+# description: |
+#   map of the block ignore directives that suppressed violations when linting, keyed by
+#   the row of the ignore-start comment, with the rules of the directive that were used
+lint.used_ignore_ranges[input.regal.file.name] := _used_ignore_ranges(_violations, ast.ignore_ranges) if {
+	"lint" in input.regal.operations
+}
+
+# bundled rules
+_violations contains violation if {
 	some category, title
 	_rules_to_run[category][title]
 
@@ -98,21 +200,21 @@ report contains violation if {
 
 	some violation in data.regal.rules[category][title].report
 
-	not _ignored(violation, ast.ignore_directives)
+	not config.disabled_in_file(violation.title)
 }
 
-# Check custom rules
-report contains violation if {
+# custom rules
+_violations contains violation if {
 	file_name_relative_to_root := trim_prefix(input.regal.file.name, concat("", [config.path_prefix, "/"]))
 	not config.ignored_globally(file_name_relative_to_root)
 
 	some category, title
 
-	violation := data.custom.regal.rules[category][title].report[_]
+	violation := _custom_report[category][title][_]
 
 	not config.ignored_rule(category, title)
 	not config.excluded_file(category, title, file_name_relative_to_root)
-	not _ignored(violation, ast.ignore_directives)
+	not config.disabled_in_file(violation.title)
 }
 
 # METADATA
@@ -122,7 +224,7 @@ aggregate[category_title] contains entry if {
 	some category, title
 	_rules_to_run[category][title]
 
-	some entry in data.regal.rules[category][title].aggregate
+	some entry in _mark_if_empty(data.regal.rules[category][title].aggregate)
 
 	category_title := concat("/", [category, title])
 }
@@ -138,15 +240,15 @@ aggregate[category_title] contains entry if {
 	not config.ignored_rule(category, title)
 	not config.excluded_file(category, title, input.regal.file.name)
 
-	entries := _mark_if_empty(data.custom.regal.rules[category][title].aggregate)
+	entries := _mark_if_empty(_custom_aggregate[category][title])
 
 	category_title := concat("/", [category, title])
 
 	some entry in entries
 }
 
-# a custom aggregate rule may not come back with entries, but we still need
-# to register the fact that it was called so that we know to call the
+# an aggregate rule may not come back with entries, but we still need to
+# register the fact that it was called so that we know to call the
 # aggregate_report for the same rule later
 #
 # for these cases we just return an empty map, and let the aggregator on the Go
@@ -156,41 +258,152 @@ _mark_if_empty(entries) := {{}} if {
 } else := entries
 
 # METADATA
-# description: Check bundled rules using aggregated data
+# description: Check bundled and custom rules using aggregated data
 # schemas:
 #   - input: schema.regal.aggregate
 aggregate_report contains violation if {
+	some violation in _reported_aggregate_violations
+
+	# some aggregate violations won't have a location at all, like no-defined-entrypoint
+	file := object.get(violation, ["location", "file"], "")
+
+	not _ignored(violation, util.keys_to_numbers(object.get(input, ["ignore_directives", file], {})))
+	not _ignored_in_range(violation, object.get(input, ["ignore_ranges", file], []))
+}
+
+# METADATA
+# description: |
+#   map of the ignore directives that suppressed violations from aggregate rules,
+#   keyed by file, in the same format as lint.used_ignore_directives
+# schemas:
+#   - input: schema.regal.aggregate
+lint.aggregate.used_ignore_directives[file] := used if {
+	"aggregate" in input.regal.operations
+
+	some file, directives in input.ignore_directives
+
+	used := _used_ignore_directives(_aggregate_violations_in(file), util.keys_to_numbers(directives))
+	count(used) > 0
+}
+
+# METADATA
+# description: |
+#   map of the block ignore directives that suppressed violations from aggregate rules,
+#   keyed by file, in the same format as lint.used_ignore_ranges
+# schemas:
+#   - input: schema.regal.aggregate
+lint.aggregate.used_ignore_ranges[file] := used if {
+	"aggregate" in input.regal.operations
+
+	some file, ranges in object.get(input, "ignore_ranges", {})
+
+	used := _used_ignore_ranges(_aggregate_violations_in(file), ranges)
+	count(used) > 0
+}
+
+# METADATA
+# description: |
+#   all violations from aggregate rules, including those ignored, at the level of the rule in
+#   the file of each violation, leaving out those in files where overrides disable the rule
+# schemas:
+#   - input: schema.regal.aggregate
+_reported_aggregate_violations contains object.union(violation, {"level": level}) if {
+	some violation in _aggregate_violations
+
+	level := _aggregate_level(violation.category, violation.title, object.get(violation, ["location", "file"], ""))
+	level != "ignore"
+}
+
+_aggregate_level(category, title, file) := _level_in_file(category, title, file) if file != ""
+
+# METADATA
+# description: |
+#   violations without a location, like from no-defined-entrypoint, concern all files, and
+#   are reported at the most severe level of the rule in any of them
+# schemas:
+#   - input: schema.regal.aggregate
+_aggregate_level(category, title, "") := level if {
+	levels := {config.level_for_rule(category, title)} | {_level_in_file(category, title, file) |
+		some file, _ in input.regal.config.files
+	}
+
+	level := _most_severe(levels)
+}
+
+# METADATA
+# description: the most severe of levels, or "ignore" if all of them are
+_most_severe(levels) := ordered[0] if {
+	ordered := [level | some level in ["error", "warning", "info", "hint"]; level in levels]
+	count(ordered) > 0
+} else := "ignore"
+
+# METADATA
+# description: |
+#   the level of a rule in file, with any overrides applying to the file, as provided by the
+#   linter for the aggregate report, as aggregate rules report on all files at once
+# schemas:
+#   - input: schema.regal.aggregate
+_level_in_file(category, title, file) := level if {
+	rules := input.regal.config.files[file].rules
+
+	# regal ignore:with-outside-test-context
+	level := config.level_for_rule(category, title) with input.regal.config.rules as rules
+} else := config.level_for_rule(category, title)
+
+# METADATA
+# description: |
+#   answers whether a rule ignored by the configuration is enabled by overrides for any of
+#   the files linted, in which case it's run for the aggregate report too
+# schemas:
+#   - input: schema.regal.aggregate
+_enabled_by_override_in_files(category, title) if {
+	config.enabled_by_override(category, title)
+
+	some file, _ in input.regal.config.files
+	_level_in_file(category, title, file) != "ignore"
+}
+
+_aggregate_rules_to_run[category] contains title if {
 	some category, title
 	_rules_to_run[category][title]
+}
+
+_aggregate_rules_to_run[category] contains title if {
+	some category, title
+	config.rules[category][title]
+
+	_enabled_by_override_in_files(category, title)
+}
+
+# METADATA
+# description: all violations from bundled aggregate rules, including those ignored
+# schemas:
+#   - input: schema.regal.aggregate
+_aggregate_violations contains violation if {
+	some category, title
+	_aggregate_rules_to_run[category][title]
 
 	key := concat("/", [category, title])
 	input_for_rule := object.remove(
-		object.union(input, {"aggregate": object.get(input, ["aggregates_internal", key], [])}),
+		object.union(input, {"aggregate": _null_to_empty(object.get(input, ["aggregates_internal", key], []))}),
 		["aggregates_internal"],
 	)
 
 	# regal ignore:with-outside-test-context
 	some violation in data.regal.rules[category][title].aggregate_report with input as input_for_rule
-
-	not _ignored(violation, util.keys_to_numbers(object.get(
-		input.ignore_directives,
-		# some aggregate violations won't have a location at all, like no-defined-entrypoint
-		object.get(violation, ["location", "file"], ""),
-		{},
-	)))
 }
 
 # METADATA
-# description: Check custom rules using aggregated data
+# description: all violations from custom aggregate rules, including those ignored
 # schemas:
 #   - input: schema.regal.aggregate
-aggregate_report contains violation if {
+_aggregate_violations contains violation if {
 	not config.ignored_globally(input.regal.file.name)
 
 	some key in object.keys(input.aggregates_internal)
 	[category, title] := split(key, "/")
 
-	not config.ignored_rule(category, title)
+	_custom_aggregate_enabled(category, title)
 	not config.excluded_file(category, title, input.regal.file.name)
 
 	input_for_rule := object.remove(
@@ -200,12 +413,17 @@ aggregate_report contains violation if {
 
 	# regal ignore:with-outside-test-context
 	some violation in data.custom.regal.rules[category][title].aggregate_report with input as input_for_rule
+}
 
-	# don't assume that the author included a location in the violation, although they really should
-	ignore_directives := object.get(input, ["ignore_directives", object.get(violation, ["location", "file"], "")], {})
+_custom_aggregate_enabled(category, title) if not config.ignored_rule(category, title)
 
-	not _ignored(violation, util.keys_to_numbers(ignore_directives))
-}
+_custom_aggregate_enabled(category, title) if _enabled_by_override_in_files(category, title)
+
+# don't assume that the author included a location in the violation, although they really should
+_aggregate_violations_in(file) := [violation |
+	some violation in _reported_aggregate_violations
+	object.get(violation, ["location", "file"], "") == file
+]
 
 _ignored(violation, directives) if {
 	ignored_rules := directives[util.to_location_object(violation.location).row]
@@ -217,6 +435,42 @@ _ignored(violation, directives) if {
 	violation.title in ignored_rules
 }
 
+_ignored_in_range(violation, ranges) if {
+	some ignore_range in ranges
+	_in_range(violation, ignore_range)
+}
+
+_in_range(violation, ignore_range) if {
+	row := util.to_location_object(violation.location).row
+
+	row >= ignore_range.start
+	row <= ignore_range.end
+	violation.title in ignore_range.rules
+}
+
+# a directive on the row before a violation, or on the same row, suppresses it,
+# where the row of a directive is that following the comment
+_used_ignore_directives(violations, directives) := {row: titles |
+	some row, rules in directives
+
+	titles := {violation.title |
+		some violation in violations
+		violation.title in rules
+		util.to_location_object(violation.location).row in {row, row - 1}
+	}
+	count(titles) > 0
+}
+
+_used_ignore_ranges(violations, ranges) := {ignore_range.start: titles |
+	some ignore_range in ranges
+
+	titles := {violation.title |
+		some violation in violations
+		_in_range(violation, ignore_range)
+	}
+	count(titles) > 0
+}
+
 _null_to_empty(x) := [] if {
 	x == null
 } else := x
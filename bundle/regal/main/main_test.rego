@@ -342,6 +342,7 @@ test_main_lint if {
 			"description": "documentation",
 			"ref": "https://www.openpolicyagent.org/projects/regal/rules/style/use-assignment-operator",
 		}],
+		"tags": ["autofixable"],
 		"title": "use-assignment-operator",
 	}}
 	result.ignore_directives == {"p.rego": {}}
@@ -425,7 +426,7 @@ test_aggregate_report_custom_rule if {
 	report := main.aggregate_report with input as mock_input
 		with data.custom.regal.rules as mock_rules
 
-	report == {{"category": "custom", "title": "test"}}
+	report == {{"category": "custom", "title": "test", "level": "error"}}
 
 	violations := main.lint.aggregate.violations with input as mock_input
 		with data.custom.regal.rules as mock_rules
@@ -443,3 +444,16 @@ test_big_number_causes_no_parser_error if {
 
 	module.rules[0].head.value.value == 1e1000
 }
+
+test_disable_file_directive_not_overridden_by_enable_all if {
+	policy := `package p
+
+# regal disable-file: prefer-snake-case
+camelCase := "yes"
+`
+	report := main.report with input as regal.parse_module("p.rego", policy)
+		with config.rules as {"style": {"prefer-snake-case": {"level": "error"}}}
+		with data.eval.params.enable_all as true
+
+	count(report) == 0
+}
@@ -0,0 +1,122 @@
+package regal.main_test
+
+import data.regal.main
+
+test_aggregate_rule_enabled_by_override_reported_at_override_level if {
+	r := main.aggregate_report with input as {
+		"aggregates_internal": {},
+		"regal": {
+			"file": {"name": "__aggregate_report__"},
+			"operations": ["aggregate"],
+			"config": {"files": {"p/p.rego": {"rules": {"idiomatic": {"no-defined-entrypoint": {"level": "warning"}}}}}},
+		},
+	}
+		with data.internal.combined_config as {
+			"rules": {"idiomatic": {"no-defined-entrypoint": {"level": "ignore"}}},
+			"overrides": [{"files": ["p/**"], "rules": {"idiomatic": {"no-defined-entrypoint": {"level": "warning"}}}}],
+		}
+		with data.regal.rules.idiomatic["no-defined-entrypoint"].aggregate_report as {_no_entrypoint}
+
+	r == {object.union(_no_entrypoint, {"level": "warning"})}
+}
+
+test_aggregate_rule_not_enabled_by_override_for_files_linted if {
+	r := main.aggregate_report with input as {
+		"aggregates_internal": {},
+		"regal": {
+			"file": {"name": "__aggregate_report__"},
+			"operations": ["aggregate"],
+			"config": {"files": {"p/p.rego": {"rules": {"idiomatic": {"no-defined-entrypoint": {"level": "ignore"}}}}}},
+		},
+	}
+		with data.internal.combined_config as {
+			"rules": {"idiomatic": {"no-defined-entrypoint": {"level": "ignore"}}},
+			"overrides": [{"files": ["other/**"], "rules": {"idiomatic": {"no-defined-entrypoint": {"level": "error"}}}}],
+		}
+		with data.regal.rules.idiomatic["no-defined-entrypoint"].aggregate_report as {_no_entrypoint}
+
+	r == set()
+}
+
+test_aggregate_violations_reported_at_level_for_file if {
+	r := main.aggregate_report with input as {
+		"aggregates_internal": {},
+		"regal": {
+			"file": {"name": "__aggregate_report__"},
+			"operations": ["aggregate"],
+			"config": {"files": {
+				"vendor/v.rego": {"rules": {"imports": {"unresolved-import": {"level": "ignore"}}}},
+				"legacy/l.rego": {"rules": {"imports": {"unresolved-import": {"level": "warning"}}}},
+			}},
+		},
+	}
+		with data.internal.combined_config as {"rules": {"imports": {"unresolved-import": {"level": "error"}}}}
+		with data.regal.rules.imports["unresolved-import"].aggregate_report as {
+			{"category": "imports", "title": "unresolved-import", "location": {"file": "p/p.rego", "row": 1}},
+			{"category": "imports", "title": "unresolved-import", "location": {"file": "vendor/v.rego", "row": 1}},
+			{"category": "imports", "title": "unresolved-import", "location": {"file": "legacy/l.rego", "row": 1}},
+		}
+
+	r == {
+		{"category": "imports", "title": "unresolved-import", "location": {"file": "p/p.rego", "row": 1}, "level": "error"},
+		{
+			"category": "imports",
+			"title": "unresolved-import",
+			"location": {"file": "legacy/l.rego", "row": 1},
+			"level": "warning",
+		},
+	}
+}
+
+test_aggregate_violation_without_location_reported_at_info_level if {
+	r := main.aggregate_report with input as _aggregate_input_without_files
+		with data.internal.combined_config as {"rules": {"idiomatic": {"no-defined-entrypoint": {"level": "info"}}}}
+		with data.regal.rules.idiomatic["no-defined-entrypoint"].aggregate_report as {_no_entrypoint}
+
+	r == {object.union(_no_entrypoint, {"level": "info"})}
+}
+
+test_aggregate_violation_without_location_reported_at_hint_level if {
+	r := main.aggregate_report with input as _aggregate_input_without_files
+		with data.internal.combined_config as {"rules": {"idiomatic": {"no-defined-entrypoint": {"level": "hint"}}}}
+		with data.regal.rules.idiomatic["no-defined-entrypoint"].aggregate_report as {_no_entrypoint}
+
+	r == {object.union(_no_entrypoint, {"level": "hint"})}
+}
+
+test_aggregate_violation_without_location_reported_at_most_severe_level_in_files if {
+	r := main.aggregate_report with input as {
+		"aggregates_internal": {},
+		"regal": {
+			"file": {"name": "__aggregate_report__"},
+			"operations": ["aggregate"],
+			"config": {"files": {
+				"p/p.rego": {"rules": {"idiomatic": {"no-defined-entrypoint": {"level": "info"}}}},
+				"q/q.rego": {"rules": {"idiomatic": {"no-defined-entrypoint": {"level": "ignore"}}}},
+			}},
+		},
+	}
+		with data.internal.combined_config as {"rules": {"idiomatic": {"no-defined-entrypoint": {"level": "hint"}}}}
+		with data.regal.rules.idiomatic["no-defined-entrypoint"].aggregate_report as {_no_entrypoint}
+
+	r == {object.union(_no_entrypoint, {"level": "info"})}
+}
+
+test_most_severe_levels if {
+	main._most_severe({"hint", "info"}) == "info"
+	main._most_severe({"hint", "ignore"}) == "hint"
+	main._most_severe({"warning", "info", "error"}) == "error"
+	main._most_severe({"ignore"}) == "ignore"
+	main._most_severe(set()) == "ignore"
+}
+
+_aggregate_input_without_files := {
+	"aggregates_internal": {},
+	"regal": {
+		"file": {"name": "__aggregate_report__"},
+		"operations": ["aggregate"],
+		"config": {"files": {}},
+	},
+}
+
+_no_entrypoint := {"category": "idiomatic", "title": "no-defined-entrypoint"}
@@ -143,10 +143,10 @@ _fail_annotated(metadata, details) := violation if {
 	is_object(metadata)
 	with_location := object.union(metadata, details)
 	category := with_location.custom.category
-	with_category := object.union(with_location, {
+	with_category := object.union(with_location, object.union(_tags(with_location.custom), {
 		"category": category,
 		"level": config.level_for_rule(category, metadata.title),
-	})
+	}))
 
 	without_custom_and_scope := object.remove(with_category, ["custom", "scope", "schemas"])
 	related_resources := _resource_urls(without_custom_and_scope.related_resources, category)
@@ -161,14 +161,20 @@ _fail_annotated_custom(metadata, details) := violation if {
 	is_object(metadata)
 	with_location := object.union(metadata, details)
 	category := with_location.custom.category
-	with_category := object.union(with_location, {
+	with_category := object.union(with_location, object.union(_tags(with_location.custom), {
 		"category": category,
 		"level": config.level_for_rule(category, metadata.title),
-	})
+	}))
 
 	violation := object.remove(with_category, ["custom", "scope", "schemas"])
 }
 
+# tags declared in the custom section of the rule's metadata, if any
+default _tags(_) := {}
+
+# regal ignore:narrow-argument
+_tags(custom) := {"tags": custom.tags} if is_array(custom.tags)
+
 _resource_urls(related_resources, category) := [r |
 	some item in related_resources
 	r := object.union(object.remove(item, ["ref"]), {"ref": config.docs.resolve_url(item.ref, category)})
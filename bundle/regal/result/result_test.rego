@@ -55,6 +55,30 @@ test_related_resources_in_result_fail_on_custom_rule_when_provided if {
 	}
 }
 
+test_tags_in_result_fail_on_custom_rule_when_provided if {
+	chain := [
+		{"path": ["custom", "regal", "rules", "category", "name", "report"]},
+		{
+			"annotations": {
+				"scope": "package",
+				"description": "This is a test",
+				"custom": {"tags": ["security"]},
+			},
+			"path": ["custom", "regal", "rules", "category", "name"],
+		},
+	]
+
+	violation := result.fail(chain, {})
+
+	violation == {
+		"category": "category",
+		"description": "This is a test",
+		"level": "error",
+		"tags": ["security"],
+		"title": "name",
+	}
+}
+
 test_related_resources_generated_by_result_fail_for_builtin_rule if {
 	chain := [
 		{"path": ["regal", "rules", "category", "name", "report"]},
@@ -1,5 +1,7 @@
 # METADATA
 # description: Constant condition
+# custom:
+#   tags: [autofixable]
 package regal.rules.bugs["constant-condition"]
 
 import data.regal.ast
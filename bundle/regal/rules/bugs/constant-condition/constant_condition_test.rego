@@ -26,6 +26,7 @@ test_fail_simple_constant_condition if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/constant-condition", "bugs"),
 		}],
+		"tags": ["autofixable"],
 		"title": "constant-condition",
 		"level": "error",
 	}}
@@ -51,6 +52,7 @@ test_fail_if_template_string_constant_condition if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/constant-condition", "bugs"),
 		}],
+		"tags": ["autofixable"],
 		"title": "constant-condition",
 		"level": "error",
 	}}
@@ -86,6 +88,7 @@ test_fail_simple_constant_condition_nested if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/constant-condition", "bugs"),
 		}],
+		"tags": ["autofixable"],
 		"title": "constant-condition",
 		"level": "error",
 	}}
@@ -112,6 +115,7 @@ test_fail_rule_with_body_looking_generated if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/constant-condition", "bugs"),
 		}],
+		"tags": ["autofixable"],
 		"title": "constant-condition",
 		"level": "error",
 	}}
@@ -138,6 +142,7 @@ test_fail_operator_constant_condition if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/constant-condition", "bugs"),
 		}],
+		"tags": ["autofixable"],
 		"title": "constant-condition",
 		"level": "error",
 	}}
@@ -167,6 +172,7 @@ test_fail_operator_constant_condition_nested if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/constant-condition", "bugs"),
 		}],
+		"tags": ["autofixable"],
 		"title": "constant-condition",
 		"level": "error",
 	}}
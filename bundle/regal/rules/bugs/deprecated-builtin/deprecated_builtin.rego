@@ -1,5 +1,7 @@
 # METADATA
 # description: Avoid using deprecated built-in functions
+# custom:
+#   tags: [rego-v1]
 package regal.rules.bugs["deprecated-builtin"]
 
 import data.regal.ast
@@ -28,6 +28,7 @@ test_fail_call_to_deprecated_builtin_function if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/deprecated-builtin", "bugs"),
 		}],
+		"tags": ["rego-v1"],
 		"title": "deprecated-builtin",
 	}}
 }
@@ -1,5 +1,7 @@
 # METADATA
 # description: Entrypoint can't be marked internal
+# custom:
+#   tags: [security]
 package regal.rules.bugs["internal-entrypoint"]
 
 import data.regal.ast
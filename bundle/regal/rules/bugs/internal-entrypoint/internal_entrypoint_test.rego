@@ -31,6 +31,7 @@ _allow := true
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/internal-entrypoint", "bugs"),
 		}],
+		"tags": ["security"],
 		"title": "internal-entrypoint",
 	}}
 }
@@ -61,6 +62,7 @@ authz._allow := true
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/internal-entrypoint", "bugs"),
 		}],
+		"tags": ["security"],
 		"title": "internal-entrypoint",
 	}}
 }
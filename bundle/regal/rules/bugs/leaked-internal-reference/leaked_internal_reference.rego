@@ -1,5 +1,7 @@
 # METADATA
 # description: Outside reference to internal rule or function
+# custom:
+#   tags: [security]
 package regal.rules.bugs["leaked-internal-reference"]
 
 import data.regal.ast
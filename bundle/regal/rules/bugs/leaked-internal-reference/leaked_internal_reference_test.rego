@@ -95,6 +95,7 @@ expected := {
 		"description": "documentation",
 		"ref": config.docs.resolve_url("$baseUrl/$category/leaked-internal-reference", "bugs"),
 	}],
+	"tags": ["security"],
 	"title": "leaked-internal-reference",
 	"location": {"file": "policy.rego"},
 }
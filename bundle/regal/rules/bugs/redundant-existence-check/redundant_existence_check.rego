@@ -1,5 +1,7 @@
 # METADATA
 # description: Redundant existence check
+# custom:
+#   tags: [autofixable]
 package regal.rules.bugs["redundant-existence-check"]
 
 import data.regal.ast
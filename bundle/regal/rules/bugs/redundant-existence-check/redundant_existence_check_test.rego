@@ -21,6 +21,7 @@ test_fail_redundant_existence_check if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/redundant-existence-check", "bugs"),
 		}],
+		"tags": ["autofixable"],
 		"title": "redundant-existence-check",
 	}}
 }
@@ -41,6 +42,7 @@ test_fail_redundant_existence_check_subset if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/redundant-existence-check", "bugs"),
 		}],
+		"tags": ["autofixable"],
 		"title": "redundant-existence-check",
 	}}
 }
@@ -80,6 +82,7 @@ test_fail_redundant_existence_check_head_assignment_of_ref if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/redundant-existence-check", "bugs"),
 		}],
+		"tags": ["autofixable"],
 		"title": "redundant-existence-check",
 	}}
 }
@@ -99,6 +102,7 @@ test_fail_redundant_existence_check_function_arg if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/redundant-existence-check", "bugs"),
 		}],
+		"tags": ["autofixable"],
 		"title": "redundant-existence-check",
 	}}
 }
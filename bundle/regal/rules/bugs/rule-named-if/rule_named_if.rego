@@ -1,5 +1,7 @@
 # METADATA
 # description: Rule named "if"
+# custom:
+#   tags: [rego-v1]
 package regal.rules.bugs["rule-named-if"]
 
 import data.regal.ast
@@ -29,6 +29,7 @@ test_fail_rule_named_if if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/rule-named-if", "bugs"),
 		}],
+		"tags": ["rego-v1"],
 		"title": "rule-named-if",
 	}} with input.regal.file.rego_version as "v0"
 		with capabilities.is_opa_v1 as false
@@ -1,5 +1,7 @@
 # METADATA
 # description: Directory structure should mirror package
+# custom:
+#   tags: [autofixable]
 package regal.rules.idiomatic["directory-package-mismatch"]
 
 import data.regal.ast
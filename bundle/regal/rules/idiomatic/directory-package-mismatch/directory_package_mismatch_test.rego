@@ -43,6 +43,7 @@ with_location(location) := {{
 		"description": "documentation",
 		"ref": config.docs.resolve_url("$baseUrl/$category/directory-package-mismatch", "idiomatic"),
 	}],
+	"tags": ["autofixable"],
 	"title": "directory-package-mismatch",
 }}
 
@@ -1,5 +1,7 @@
 # METADATA
 # description: Use raw strings for regex patterns
+# custom:
+#   tags: [autofixable]
 package regal.rules.idiomatic["non-raw-regex-pattern"]
 
 import data.regal.ast
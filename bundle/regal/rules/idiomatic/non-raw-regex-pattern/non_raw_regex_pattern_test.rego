@@ -24,6 +24,7 @@ test_fail_non_raw_rule_head if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/non-raw-regex-pattern", "idiomatic"),
 		}],
+		"tags": ["autofixable"],
 		"title": "non-raw-regex-pattern",
 	}}
 }
@@ -48,6 +49,7 @@ test_fail_non_raw_rule_body if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/non-raw-regex-pattern", "idiomatic"),
 		}],
+		"tags": ["autofixable"],
 		"title": "non-raw-regex-pattern",
 	}}
 }
@@ -70,6 +72,7 @@ test_fail_pattern_in_second_arg if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/non-raw-regex-pattern", "idiomatic"),
 		}],
+		"tags": ["autofixable"],
 		"title": "non-raw-regex-pattern",
 	}}
 }
@@ -1,5 +1,7 @@
 # METADATA
 # description: Prefer `==` for equality comparison
+# custom:
+#   tags: [autofixable]
 package regal.rules.idiomatic["prefer-equals-comparison"]
 
 import data.regal.ast
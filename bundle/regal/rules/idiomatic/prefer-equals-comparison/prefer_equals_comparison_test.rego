@@ -28,6 +28,7 @@ test_fail_impossible_assignment_2_constants if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/prefer-equals-comparison", "idiomatic"),
 		}],
+		"tags": ["autofixable"],
 		"title": "prefer-equals-comparison",
 	}}
 }
@@ -53,6 +54,7 @@ test_fail_impossible_assignment_static_ref_equals_constant if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/prefer-equals-comparison", "idiomatic"),
 		}],
+		"tags": ["autofixable"],
 		"title": "prefer-equals-comparison",
 	}}
 }
@@ -78,6 +80,7 @@ test_fail_impossible_assignment_constant_equals_static_ref if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/prefer-equals-comparison", "idiomatic"),
 		}],
+		"tags": ["autofixable"],
 		"title": "prefer-equals-comparison",
 	}}
 }
@@ -106,6 +109,7 @@ test_fail_impossible_assignment_input_var_equals_static_ref if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/prefer-equals-comparison", "idiomatic"),
 		}],
+		"tags": ["autofixable"],
 		"title": "prefer-equals-comparison",
 	}}
 }
@@ -1,5 +1,7 @@
 # METADATA
 # description: Use the `contains` keyword
+# custom:
+#   tags: [rego-v1]
 package regal.rules.idiomatic["use-contains"]
 
 import data.regal.ast
@@ -1,5 +1,7 @@
 # METADATA
 # description: Use the `if` keyword
+# custom:
+#   tags: [rego-v1]
 package regal.rules.idiomatic["use-if"]
 
 import data.regal.ast
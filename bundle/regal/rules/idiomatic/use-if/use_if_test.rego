@@ -31,6 +31,7 @@ test_fail_should_use_if if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/use-if", "idiomatic"),
 		}],
+		"tags": ["rego-v1"],
 		"title": "use-if",
 	}}
 }
@@ -1,5 +1,7 @@
 # METADATA
 # description: Use explicit future keyword imports
+# custom:
+#   tags: [rego-v1]
 package regal.rules.imports["implicit-future-keywords"]
 
 import data.regal.config
@@ -14,6 +14,7 @@ test_fail_future_keywords_import_wildcard if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/implicit-future-keywords", "imports"),
 		}],
+		"tags": ["rego-v1"],
 		"title": "implicit-future-keywords",
 		"location": {
 			"col": 8,
@@ -1,5 +1,7 @@
 # METADATA
 # description: Use `import rego.v1`
+# custom:
+#   tags: [autofixable, rego-v1]
 package regal.rules.imports["use-rego-v1"]
 
 import data.regal.ast
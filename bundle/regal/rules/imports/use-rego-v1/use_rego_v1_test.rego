@@ -19,6 +19,7 @@ test_fail_missing_rego_v1_import if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/use-rego-v1", "imports"),
 		}],
+		"tags": ["autofixable", "rego-v1"],
 		"title": "use-rego-v1",
 		"location": {
 			"col": 1,
@@ -1,5 +1,7 @@
 # METADATA
 # description: Assignment can be deferred
+# custom:
+#   tags: [performance]
 package regal.rules.performance["defer-assignment"]
 
 import data.regal.ast
@@ -174,5 +174,6 @@ with_location(location) := {
 		"description": "documentation",
 		"ref": config.docs.resolve_url("$baseUrl/$category/defer-assignment", "performance"),
 	}],
+	"tags": ["performance"],
 	"title": "defer-assignment",
 }
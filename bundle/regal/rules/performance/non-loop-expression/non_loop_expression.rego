@@ -1,5 +1,7 @@
 # METADATA
 # description: Non-loop expression
+# custom:
+#   tags: [performance]
 package regal.rules.performance["non-loop-expression"]
 
 import data.regal.ast
@@ -336,6 +336,7 @@ with_location(location) := {{
 		"description": "documentation",
 		"ref": config.docs.resolve_url("$baseUrl/$category/non-loop-expression", "performance"),
 	}],
+	"tags": ["performance"],
 	"title": "non-loop-expression",
 	"location": location,
 	"level": "error",
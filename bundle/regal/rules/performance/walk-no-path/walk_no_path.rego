@@ -1,5 +1,7 @@
 # METADATA
 # description: Call to `walk` can be optimized
+# custom:
+#   tags: [performance]
 package regal.rules.performance["walk-no-path"]
 
 import data.regal.ast
@@ -89,5 +89,6 @@ with_location(location) := {
 		"description": "documentation",
 		"ref": config.docs.resolve_url("$baseUrl/$category/walk-no-path", "performance"),
 	}],
+	"tags": ["performance"],
 	"title": "walk-no-path",
 }
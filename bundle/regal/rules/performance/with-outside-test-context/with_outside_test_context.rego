@@ -1,5 +1,7 @@
 # METADATA
 # description: '`with` used outside test context'
+# custom:
+#   tags: [performance]
 package regal.rules.performance["with-outside-test-context"]
 
 import data.regal.ast
@@ -30,6 +30,7 @@ test_fail_with_used_outside_test if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/with-outside-test-context", "performance"),
 		}],
+		"tags": ["performance"],
 		"title": "with-outside-test-context",
 	}}
 }
@@ -1,5 +1,7 @@
 # METADATA
 # description: Comment should start with whitespace
+# custom:
+#   tags: [autofixable]
 package regal.rules.style["no-whitespace-comment"]
 
 import data.regal.ast
@@ -14,6 +14,7 @@ test_fail_no_leading_whitespace if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/no-whitespace-comment", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "no-whitespace-comment",
 		"location": {
 			"col": 1,
@@ -39,6 +40,7 @@ test_fail_no_leading_whitespace_multiple_hashes if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/no-whitespace-comment", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "no-whitespace-comment",
 		"location": {
 			"col": 1,
@@ -1,5 +1,7 @@
 # METADATA
 # description: File should be formatted with `opa fmt`
+# custom:
+#   tags: [autofixable]
 package regal.rules.style["opa-fmt"]
 
 import data.regal.result
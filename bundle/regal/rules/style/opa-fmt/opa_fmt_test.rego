@@ -21,6 +21,7 @@ test_fail_not_formatted if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/opa-fmt", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "opa-fmt",
 	}}
 }
@@ -50,6 +51,7 @@ test_fail_v0_required_but_v1_policy if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/opa-fmt", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "opa-fmt",
 	}}
 }
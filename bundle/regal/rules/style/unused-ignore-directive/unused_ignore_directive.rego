@@ -0,0 +1,10 @@
+# METADATA
+# description: Unused ignore directive
+package regal.rules.style["unused-ignore-directive"]
+
+# METADATA
+# description: |
+#   whether an ignore directive suppressed any violation is only known once all other
+#   rules have been evaluated, including aggregate rules, so violations of this rule
+#   are reported by the linter after linting, for files where this rule is enabled
+report := set()
@@ -0,0 +1,13 @@
+package regal.rules.style["unused-ignore-directive_test"]
+
+import data.regal.ast
+import data.regal.rules.style["unused-ignore-directive"] as rule
+
+test_no_violations_reported_by_rule_itself if {
+	r := rule.report with input as ast.with_rego_v1(`
+# regal ignore:todo-comment
+allow := true
+`)
+
+	r == set()
+}
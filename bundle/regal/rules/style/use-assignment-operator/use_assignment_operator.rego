@@ -1,5 +1,7 @@
 # METADATA
 # description: Prefer := over = for assignment
+# custom:
+#   tags: [autofixable]
 package regal.rules.style["use-assignment-operator"]
 
 import data.regal.ast
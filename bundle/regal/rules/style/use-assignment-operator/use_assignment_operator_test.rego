@@ -13,6 +13,7 @@ test_fail_unification_in_regular_assignment if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/use-assignment-operator", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "use-assignment-operator",
 		"location": {
 			"col": 5,
@@ -38,6 +39,7 @@ test_fail_not_implicit_boolean_assignment_with_body if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/use-assignment-operator", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "use-assignment-operator",
 		"location": {
 			"col": 7,
@@ -62,6 +64,7 @@ test_fail_not_implicit_boolean_assignment if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/use-assignment-operator", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "use-assignment-operator",
 		"location": {
 			"col": 5,
@@ -91,6 +94,7 @@ test_fail_unification_in_default_assignment if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/use-assignment-operator", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "use-assignment-operator",
 		"location": {
 			"col": 11,
@@ -115,6 +119,7 @@ test_fail_unification_in_default_function_assignment if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/use-assignment-operator", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "use-assignment-operator",
 		"location": {
 			"col": 14,
@@ -149,6 +154,7 @@ test_fail_unification_in_object_rule_assignment if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/use-assignment-operator", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "use-assignment-operator",
 		"location": {
 			"col": 8,
@@ -178,6 +184,7 @@ test_fail_unification_in_function_assignment if {
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/use-assignment-operator", "style"),
 		}],
+		"tags": ["autofixable"],
 		"title": "use-assignment-operator",
 		"location": {
 			"col": 10,
@@ -242,6 +249,7 @@ test_fail_unification_in_else if {
 				"description": "documentation",
 				"ref": config.docs.resolve_url("$baseUrl/$category/use-assignment-operator", "style"),
 			}],
+			"tags": ["autofixable"],
 			"title": "use-assignment-operator",
 			"location": {"col": 9, "file": "policy.rego", "row": 6, "text": "\t} else = true if {", "end": {
 				"col": 10,
@@ -256,6 +264,7 @@ test_fail_unification_in_else if {
 				"description": "documentation",
 				"ref": config.docs.resolve_url("$baseUrl/$category/use-assignment-operator", "style"),
 			}],
+			"tags": ["autofixable"],
 			"title": "use-assignment-operator",
 			"location": {"col": 9, "file": "policy.rego", "row": 8, "text": "\t} else = false", "end": {
 				"col": 10,
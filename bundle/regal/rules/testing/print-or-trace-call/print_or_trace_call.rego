@@ -1,5 +1,7 @@
 # METADATA
 # description: Call to print or trace function
+# custom:
+#   tags: [security]
 package regal.rules.testing["print-or-trace-call"]
 
 import data.regal.ast
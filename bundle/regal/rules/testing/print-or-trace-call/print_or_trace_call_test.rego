@@ -41,5 +41,6 @@ expected_with_location(location) := {
 		"description": "documentation",
 		"ref": config.docs.resolve_url("$baseUrl/$category/print-or-trace-call", "testing"),
 	}],
+	"tags": ["security"],
 	"title": "print-or-trace-call",
 }
@@ -0,0 +1,439 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	outil "github.com/open-policy-agent/opa/v1/util"
+
+	rio "github.com/open-policy-agent/regal/internal/io"
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/config"
+	"github.com/open-policy-agent/regal/pkg/linter"
+)
+
+type configCommandParams struct {
+	configFile string
+	rules      repeatedStringFlag
+	effective  bool
+}
+
+func init() {
+	params := configCommandParams{}
+
+	configCommand := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and validate Regal configuration",
+		Long:  "Commands for inspecting and validating the Regal configuration in use.",
+	}
+
+	printCommand := &cobra.Command{
+		Use:   "print [path]",
+		Short: "Print the resolved configuration",
+		Long: `Print the user configuration found for the provided path, or the current directory, with any
+configuration files it extends resolved and merged. Each setting is annotated with the file it was set in.
+If no configuration file is found, the default configuration is printed.
+
+With --effective, the configuration is instead printed as used when linting, i.e. merged on top of the
+configuration provided by Regal, with the level of each rule resolved. If the path provided is a file, the
+configuration is resolved for that file, i.e. with any overrides matching the path applied, as when linting
+the file by the same path.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return printConfig(os.Stdout, args, params)
+		},
+	}
+
+	printCommand.Flags().BoolVar(&params.effective, "effective", false,
+		"print the effective configuration, merged with the configuration provided by Regal")
+
+	validateCommand := &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Validate the configuration",
+		Long: `Validate the user configuration found for the provided path, or the current directory, and report
+any unknown settings, categories, rules or rule attributes, along with the line and column where found.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			if err := validateConfig(os.Stdout, args, params); err != nil {
+				log.SetOutput(os.Stderr)
+				log.Println(err)
+
+				return exit(1)
+			}
+
+			return nil
+		},
+	}
+
+	validateCommand.Flags().VarP(&params.rules, "rules", "r",
+		"set custom rules file(s) to validate configuration against. This flag can be repeated.")
+
+	explainCommand := &cobra.Command{
+		Use:   "explain <rule> [path]",
+		Short: "Explain the configuration of a rule",
+		Long: `Explain the configuration of a rule, i.e. its level, attributes and ignore patterns, and where each
+was set. The rule may be provided by name, or as category/name. If no configuration file is found, the
+default configuration is explained.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return explainRule(os.Stdout, args[0], args[1:], params)
+		},
+	}
+
+	for _, command := range []*cobra.Command{printCommand, validateCommand, explainCommand} {
+		command.Flags().StringVarP(&params.configFile, "config-file", "c", "", "set path of configuration file")
+		configCommand.AddCommand(command)
+	}
+
+	RootCommand.AddCommand(configCommand)
+}
+
+func printConfig(w io.Writer, args []string, params configCommandParams) error {
+	conf, err := loadConfigWithSources(args, params)
+	if err != nil {
+		return err
+	}
+
+	if params.effective {
+		effective, err := effectiveConfig(conf)
+		if err != nil {
+			return err
+		}
+
+		conf = *effective
+
+		if len(args) > 0 && rio.IsFile(args[0]) {
+			if conf, err = configForPath(conf, args[0], getSearchPath(args)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the capabilities are listed in full once resolved, which isn't helpful here,
+	// and capabilities_url is included if not the default
+	conf.Capabilities = nil
+
+	bs, err := config.ToYAMLWithSources(conf, conf.Sources)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(bs)
+
+	return err
+}
+
+func validateConfig(w io.Writer, args []string, params configCommandParams) error {
+	searchPath := getSearchPath(args)
+
+	file, err := readUserConfig(lintAndFixParams{configFile: params.configFile}, searchPath)
+	if err != nil || file == nil {
+		return fmt.Errorf("failed to find configuration file: %w", err)
+	}
+
+	defer rio.CloseIgnore(file)
+
+	name := relativePath(file.Name())
+
+	var node yaml.Node
+	if err := yaml.NewDecoder(file).Decode(&node); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: failed to decode configuration: %w", name, err)
+	}
+
+	l := linter.NewLinter()
+
+	if regalDir, err := config.FindRegalDirectory(searchPath); err == nil {
+		defer rio.CloseIgnore(regalDir)
+
+		if customRulesPath := filepath.Join(regalDir.Name(), "rules"); rio.IsDir(customRulesPath) {
+			l = l.WithCustomRules([]string{customRulesPath})
+		}
+	}
+
+	if params.rules.isSet {
+		l = l.WithCustomRules(params.rules.v)
+	}
+
+	schema, err := l.Schema()
+	if err != nil {
+		return err
+	}
+
+	problems := config.Validate(&node, schema)
+
+	for _, problem := range problems {
+		fmt.Fprintf(w, "%s:%s\n", name, problem)
+	}
+
+	// settings validated, but other errors may still occur when loading the configuration,
+	// like from files extended, or capabilities not found
+	if len(problems) == 0 {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to read configuration file: %w", err)
+		}
+
+		if _, err := config.FromFile(file); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		fmt.Fprintf(w, "%s: no problems found\n", name)
+
+		return nil
+	}
+
+	return fmt.Errorf("invalid configuration in %s", name)
+}
+
+func explainRule(w io.Writer, rule string, args []string, params configCommandParams) error {
+	conf, err := loadConfigWithSources(args, params)
+	if err != nil {
+		return err
+	}
+
+	effective, err := effectiveConfig(conf)
+	if err != nil {
+		return err
+	}
+
+	category, title, found := strings.Cut(rule, "/")
+	if !found {
+		title = rule
+		category = ""
+
+		for name, rules := range effective.Rules {
+			if _, ok := rules[title]; ok {
+				category = name
+
+				break
+			}
+		}
+	}
+
+	ruleConf, ok := effective.Rules[category][title]
+	if !ok {
+		return fmt.Errorf("unknown rule %s", rule)
+	}
+
+	sources := effective.Sources
+	setting := "rules." + category + "." + title + "."
+
+	fmt.Fprintf(w, "%s/%s\n\n", category, title)
+
+	levelSource := describeSource(sources, setting+"level")
+
+	switch {
+	case sources[setting+"level"] != "":
+	case sources["rules."+category+".default.level"] != "":
+		levelSource = "default level of category, " + describeSource(sources, "rules."+category+".default.level")
+	case sources["rules.default.level"] != "":
+		levelSource = "default level of all rules, " + describeSource(sources, "rules.default.level")
+	}
+
+	fmt.Fprintf(w, "level: %s (%s)\n", ruleConf.Level, levelSource)
+
+	for _, attribute := range util.Sorted(outil.Keys(ruleConf.Extra)) {
+		fmt.Fprintf(w, "%s: %v (%s)\n", attribute, ruleConf.Extra[attribute], describeSource(sources, setting+attribute))
+	}
+
+	if ruleConf.Ignore != nil && len(ruleConf.Ignore.Files) > 0 {
+		fmt.Fprintf(w, "ignore: %s (%s)\n",
+			strings.Join(ruleConf.Ignore.Files, ", "), describeSource(sources, setting+"ignore"))
+	}
+
+	if len(effective.Ignore.Files) > 0 {
+		fmt.Fprintf(w, "ignore (all rules): %s (%s)\n",
+			strings.Join(effective.Ignore.Files, ", "), describeSource(sources, "ignore"))
+	}
+
+	for i, override := range effective.Overrides {
+		overridden, ok := override.Rules[category][title]
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(w, "\noverride for %s (%s)\n",
+			strings.Join(override.Files, ", "), describeSource(sources, "overrides."+strconv.Itoa(i)))
+
+		if overridden.Level != "" {
+			fmt.Fprintf(w, "  level: %s\n", overridden.Level)
+		}
+
+		for _, attribute := range util.Sorted(outil.Keys(overridden.Extra)) {
+			fmt.Fprintf(w, "  %s: %v\n", attribute, overridden.Extra[attribute])
+		}
+
+		if overridden.Ignore != nil {
+			fmt.Fprintf(w, "  ignore: %s\n", strings.Join(overridden.Ignore.Files, ", "))
+		}
+	}
+
+	return nil
+}
+
+// loadConfigWithSources loads the user configuration for the path in args, if any, with each source
+// made relative to the current directory. If no configuration file is found, and none was provided,
+// the default configuration is returned, with "default" as the source of each setting.
+func loadConfigWithSources(args []string, params configCommandParams) (config.Config, error) {
+	file, err := readUserConfig(lintAndFixParams{configFile: params.configFile}, getSearchPath(args))
+	if (err != nil || file == nil) && params.configFile == "" {
+		return defaultConfigWithSources()
+	}
+
+	if err != nil || file == nil {
+		return config.Config{}, fmt.Errorf("failed to find configuration file: %w", err)
+	}
+
+	defer rio.CloseIgnore(file)
+
+	conf, err := config.FromFile(file)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return config.Config{}, fmt.Errorf("failed to load configuration from %s: %w", file.Name(), err)
+	}
+
+	for setting, source := range conf.Sources {
+		if _, ok := config.PresetFromSource(source); !ok {
+			conf.Sources[setting] = relativePath(source)
+		}
+	}
+
+	return conf, nil
+}
+
+func defaultConfigWithSources() (config.Config, error) {
+	conf, err := linter.NewLinter().GetConfig()
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	if conf.Sources, err = config.SourcesOf(*conf, "default"); err != nil {
+		return config.Config{}, err
+	}
+
+	return *conf, nil
+}
+
+// effectiveConfig returns the configuration used when linting with conf. A rule configured in conf
+// replaces the rule provided by Regal, so any attributes provided but not set in conf, like the
+// max-line-length of line-length when only its level is set, are added back to show their values.
+func effectiveConfig(conf config.Config) (*config.Config, error) {
+	provided, err := linter.NewLinter().GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	effective, err := linter.NewLinter().WithUserConfig(conf).GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	for category, rules := range conf.Rules {
+		for title := range rules {
+			providedRule, ok := provided.Rules[category][title]
+			if !ok {
+				continue
+			}
+
+			rule := effective.Rules[category][title]
+			extra := make(config.ExtraAttributes, len(providedRule.Extra)+len(rule.Extra))
+
+			maps.Copy(extra, providedRule.Extra)
+			maps.Copy(extra, rule.Extra)
+
+			if len(extra) > 0 {
+				rule.Extra = extra
+			}
+
+			effective.Rules[category][title] = rule
+		}
+	}
+
+	return effective, nil
+}
+
+// configForPath returns conf with the overrides matching path applied, and the settings of rules set by
+// those overrides annotated with the source of the override. Overrides are matched against the path as
+// provided, with the same prefix as when linting the file.
+func configForPath(conf config.Config, path, searchPath string) (config.Config, error) {
+	pathPrefix, _ := config.FindRegalDirectoryPath(searchPath)
+	sources := maps.Clone(conf.Sources)
+
+	for i, override := range conf.Overrides {
+		matching, err := config.Config{Overrides: []config.Override{override}}.MatchingOverrides(path, pathPrefix)
+		if err != nil {
+			return config.Config{}, fmt.Errorf("failed to determine config for %s: %w", path, err)
+		}
+
+		source, ok := conf.Sources["overrides."+strconv.Itoa(i)]
+		if len(matching) == 0 || !ok {
+			continue
+		}
+
+		for category, rules := range override.Rules {
+			for title, rule := range rules {
+				setting := "rules." + category + "." + title + "."
+
+				if rule.Level != "" {
+					sources[setting+"level"] = source
+				}
+
+				if rule.Ignore != nil {
+					sources[setting+"ignore"] = source
+				}
+
+				for attribute := range rule.Extra {
+					sources[setting+attribute] = source
+				}
+			}
+		}
+	}
+
+	pathConf, err := conf.ForPath(path, pathPrefix)
+	if err != nil {
+		return config.Config{}, fmt.Errorf("failed to determine config for %s: %w", path, err)
+	}
+
+	pathConf.Sources = sources
+
+	return pathConf, nil
+}
+
+// describeSource describes the source of a setting, or that it's provided by Regal if the setting
+// isn't found in sources.
+func describeSource(sources config.Sources, setting string) string {
+	source, ok := sources[setting]
+	if !ok {
+		return "provided by Regal"
+	}
+
+	if preset, ok := config.PresetFromSource(source); ok {
+		return "preset " + preset
+	}
+
+	return source
+}
+
+// relativePath returns path relative to the current directory, if possible.
+func relativePath(path string) string {
+	if wd := rio.Getwd(); wd != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			if rel, err := filepath.Rel(wd, abs); err == nil {
+				return rel
+			}
+		}
+	}
+
+	return path
+}
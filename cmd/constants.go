@@ -3,16 +3,31 @@ package cmd
 const (
 	// formatJSON is the JSON format value for the --format flag in various commands.
 	formatJSON = "json"
+	// formatJSONV2 is the versioned JSON format value for the --format flag in various commands.
+	formatJSONV2 = "json-v2"
 	// formatPretty is the pretty format value for the --format flag in various commands.
 	formatPretty = "pretty"
 	// formatCompact is the compact format value for the --format flag in various commands.
 	formatCompact = "compact"
 	// formatGitHub is the GitHub format value for the --format flag in various commands.
 	formatGitHub = "github"
+	// formatGitLab is the GitLab Code Quality format value for the --format flag in various commands.
+	formatGitLab = "gitlab"
 	// formatFestive is the festive format value for the --format flag in various commands.
 	formatFestive = "festive"
 	// formatSarif is the SARIF format value for the --format flag in various commands.
 	formatSarif = "sarif"
 	// formatJunit is the JUnit format value for the --format flag in various commands.
 	formatJunit = "junit"
+	// formatCheckstyle is the Checkstyle XML format value for the --format flag in various commands.
+	formatCheckstyle = "checkstyle"
+	// formatHTML is the HTML format value for the --format flag in various commands.
+	formatHTML = "html"
+	// formatMarkdown is the Markdown format value for the --format flag in various commands.
+	formatMarkdown = "markdown"
+	// formatTemplate is the format value for the --format flag in various commands, for output rendered
+	// using the template provided by the --template flag.
+	formatTemplate = "template"
+	// formatNDJSON is the newline delimited JSON format value for the --format flag in various commands.
+	formatNDJSON = "ndjson"
 )
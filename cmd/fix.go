@@ -12,7 +12,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	"github.com/open-policy-agent/regal/internal/git"
 	rio "github.com/open-policy-agent/regal/internal/io"
@@ -134,12 +133,15 @@ func fix(args []string, params *fixParams) (err error) {
 	}
 
 	l := linter.NewLinter().
+		WithPreset(params.preset).
 		WithDisableAll(params.disableAll).
 		WithDisabledCategories(params.disableCategory.v...).
 		WithDisabledRules(params.disable.v...).
 		WithEnableAll(params.enableAll).
 		WithEnabledCategories(params.enableCategory.v...).
 		WithEnabledRules(params.enable.v...).
+		WithDisabledTags(params.disableTag.v...).
+		WithEnabledTags(params.enableTag.v...).
 		WithDebugMode(params.debug)
 
 	if customRulesDir != "" {
@@ -169,7 +171,7 @@ func fix(args []string, params *fixParams) (err error) {
 			log.Printf("found user config file: %s", userConfigFile.Name())
 		}
 
-		if err := yaml.NewDecoder(userConfigFile).Decode(&userConfig); errors.Is(err, io.EOF) {
+		if userConfig, err = config.FromFile(userConfigFile); errors.Is(err, io.EOF) {
 			log.Printf("user config file %q is empty, will use the default config", userConfigFile.Name())
 		} else if err != nil {
 			if regalDir != nil {
@@ -7,27 +7,35 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/jstemmer/go-junit-report/v2/junit"
 	"github.com/spf13/cobra"
 
+	"github.com/open-policy-agent/opa/v1/ast"
 	"github.com/open-policy-agent/opa/v1/bundle"
 	"github.com/open-policy-agent/opa/v1/metrics"
 	"github.com/open-policy-agent/opa/v1/topdown"
 
 	rbundle "github.com/open-policy-agent/regal/bundle"
+	"github.com/open-policy-agent/regal/internal/baseline"
 	"github.com/open-policy-agent/regal/internal/cache"
+	"github.com/open-policy-agent/regal/internal/git"
 	rio "github.com/open-policy-agent/regal/internal/io"
 	regalmetrics "github.com/open-policy-agent/regal/internal/metrics"
 	"github.com/open-policy-agent/regal/internal/update"
+	"github.com/open-policy-agent/regal/internal/util"
 	"github.com/open-policy-agent/regal/pkg/config"
 	"github.com/open-policy-agent/regal/pkg/linter"
 	"github.com/open-policy-agent/regal/pkg/report"
 	"github.com/open-policy-agent/regal/pkg/reporter"
+	"github.com/open-policy-agent/regal/pkg/rules"
 	"github.com/open-policy-agent/regal/pkg/version"
 )
 
@@ -35,11 +43,14 @@ type lintAndFixParams struct {
 	configFile      string
 	format          string
 	outputFile      string
+	preset          string
 	rules           repeatedStringFlag
 	disable         repeatedStringFlag
 	disableCategory repeatedStringFlag
 	enable          repeatedStringFlag
 	enableCategory  repeatedStringFlag
+	disableTag      repeatedStringFlag
+	enableTag       repeatedStringFlag
 	ignoreFiles     repeatedStringFlag
 	timeout         time.Duration
 	debug           bool
@@ -50,11 +61,23 @@ type lintAndFixParams struct {
 type lintParams struct {
 	lintAndFixParams
 
-	failLevel   string
-	enablePrint bool
-	metrics     bool
-	profile     bool
-	instrument  bool
+	failLevel      string
+	cacheDir       string
+	baseline       string
+	diffBase       string
+	profileOutput  string
+	concurrency    int
+	maxWarnings    int
+	markdownLimit  int
+	templateFile   string
+	ruleTimeout    time.Duration
+	enablePrint    bool
+	staged         bool
+	updateBaseline bool
+	unusedIgnores  bool
+	metrics        bool
+	profile        bool
+	instrument     bool
 }
 
 func (params *lintAndFixParams) outputWriter() (io.Writer, error) {
@@ -74,11 +97,14 @@ func setCommonFlags(cmd *cobra.Command, params *lintAndFixParams) {
 	flags := cmd.Flags()
 	flags.StringVarP(&params.configFile, "config-file", "c", "", "set path of configuration file")
 	flags.StringVarP(&params.format, "format", "f", formatPretty,
-		"set output format (pretty, compact, json, github, sarif)")
+		"set output format "+
+			"(pretty, compact, json, json-v2, github, gitlab, sarif, junit, checkstyle, html, markdown, ndjson, template)")
 	flags.StringVarP(&params.outputFile, "output-file", "o", "",
 		"set file to use for linting output, defaults to stdout")
 	flags.BoolVar(&color.NoColor, "no-color", false, "disable color output")
 	flags.VarP(&params.rules, "rules", "r", "set custom rules file(s). This flag can be repeated.")
+	flags.StringVar(&params.preset, "preset", "",
+		"apply built-in preset of rules beneath configuration ("+strings.Join(config.Presets(), ", ")+")")
 	flags.DurationVar(&params.timeout, "timeout", 0, "set timeout for linting (default no timeout)")
 	flags.BoolVar(&params.debug, "debug", false,
 		"enable debug logging (including print output from custom policy)")
@@ -90,6 +116,10 @@ func setCommonFlags(cmd *cobra.Command, params *lintAndFixParams) {
 	flags.BoolVarP(&params.enableAll, "enable-all", "E", false, "enable all rules")
 	flags.VarP(&params.enableCategory, "enable-category", "",
 		"enable all rules in a category. This flag can be repeated.")
+	flags.VarP(&params.disableTag, "disable-tag", "",
+		"disable all rules with a tag. This flag can be repeated.")
+	flags.VarP(&params.enableTag, "enable-tag", "",
+		"enable all rules with a tag. This flag can be repeated.")
 	flags.VarP(&params.ignoreFiles, "ignore-files", "",
 		"ignore all files matching a glob-pattern. This flag can be repeated.")
 
@@ -99,6 +129,9 @@ func setCommonFlags(cmd *cobra.Command, params *lintAndFixParams) {
 	}
 }
 
+// failLevels are the levels accepted by --fail-level, ordered by severity.
+var failLevels = map[string]int{"hint": 0, "info": 1, "warning": 2, "error": 3}
+
 func init() {
 	params := &lintParams{}
 
@@ -111,6 +144,26 @@ func init() {
 				return errors.New("at least one file or directory must be provided for linting")
 			}
 
+			if params.updateBaseline && params.baseline == "" {
+				return errors.New("--update-baseline requires --baseline to be set")
+			}
+
+			if params.concurrency < 0 {
+				return errors.New("--concurrency must not be negative")
+			}
+
+			if params.markdownLimit < 0 {
+				return errors.New("--markdown-size-limit must not be negative")
+			}
+
+			if (params.format == formatTemplate) != (params.templateFile != "") {
+				return errors.New("--template must be provided with, and only with, --format template")
+			}
+
+			if _, ok := failLevels[params.failLevel]; !ok {
+				return fmt.Errorf("invalid --fail-level %q, must be one of error, warning, info, hint", params.failLevel)
+			}
+
 			return nil
 		},
 		RunE: wrapProfiling(func(args []string) error {
@@ -122,28 +175,25 @@ func init() {
 				return exit(1)
 			}
 
-			errorsFound, warningsFound := 0, 0
+			exitCode := 0
 
 			for i := range rep.Violations {
-				switch rep.Violations[i].Level {
-				case "error":
-					errorsFound++
-				case "warning":
-					warningsFound++
+				severity, ok := failLevels[rep.Violations[i].Level]
+				if !ok || severity < failLevels[params.failLevel] {
+					continue
 				}
-			}
-
-			exitCode := 0
-			if params.failLevel == "error" && errorsFound > 0 {
-				exitCode = 3
-			}
 
-			if params.failLevel == "warning" {
-				if errorsFound > 0 {
+				if rep.Violations[i].Level == "error" {
 					exitCode = 3
-				} else if warningsFound > 0 {
-					exitCode = 2
+
+					break
 				}
+
+				exitCode = 2
+			}
+
+			if exitCode == 0 && slices.ContainsFunc(rep.Thresholds, report.Threshold.Exceeded) {
+				exitCode = 2
 			}
 
 			if exitCode != 0 {
@@ -157,12 +207,37 @@ func init() {
 	setCommonFlags(lintCommand, &params.lintAndFixParams)
 
 	lintCommand.Flags().StringVarP(&params.failLevel, "fail-level", "l", "error",
-		"set level at which to fail with a non-zero exit code (error, warning)")
+		"set level at which to fail with a non-zero exit code (error, warning, info, hint)")
+	lintCommand.Flags().IntVar(&params.maxWarnings, "max-warnings", -1,
+		"set max number of warnings allowed before failing with a non-zero exit code, overriding config (-1 = no limit)")
+	lintCommand.Flags().IntVar(&params.markdownLimit, "markdown-size-limit", 0,
+		"set max size in bytes of the report when using the markdown format, leaving out violations that don't fit")
+	lintCommand.Flags().StringVar(&params.templateFile, "template", "",
+		"set path of Go text/template file to render the report with, when using the template format")
+	lintCommand.Flags().StringVar(&params.cacheDir, "cache-dir", "",
+		"set directory to cache lint results in, so that unchanged files aren't evaluated again")
+	lintCommand.Flags().StringVar(&params.baseline, "baseline", "",
+		"set path of baseline file, and only report violations not found in the baseline")
+	lintCommand.Flags().BoolVar(&params.updateBaseline, "update-baseline", false,
+		"write the violations found to the file provided by --baseline, replacing its contents")
+	lintCommand.Flags().BoolVar(&params.unusedIgnores, "report-unused-ignores", false,
+		"report ignore directives that didn't suppress any violation, as violations of the unused-ignore-directive rule")
+	lintCommand.Flags().StringVar(&params.diffBase, "diff-base", "",
+		"only report violations on lines added or modified since forking off the provided git revision (e.g. main)")
+	lintCommand.Flags().BoolVar(&params.staged, "staged", false,
+		"lint the contents of files as staged in the git index rather than on disk, e.g. for pre-commit hooks")
+	lintCommand.Flags().IntVar(&params.concurrency, "concurrency", 0,
+		"set max number of files to lint concurrently, reading files only as needed to limit memory usage (0 = no limit)")
+	lintCommand.Flags().DurationVar(&params.ruleTimeout, "rule-timeout", 0,
+		"set time budget for evaluating the rules of a file, skipping any single rule exceeding it (default no budget)")
 	lintCommand.Flags().BoolVar(&params.enablePrint, "enable-print", false, "enable print output from policy")
 	lintCommand.Flags().BoolVar(&params.metrics, "metrics", false,
 		"enable metrics reporting (currently supported only for JSON output format)")
 	lintCommand.Flags().BoolVar(&params.profile, "profile", false,
-		"enable profiling metrics to be added to reporting (currently supported only for JSON output format)")
+		"enable profiling metrics to be added to reporting "+
+			"(currently supported only for JSON, HTML and pretty output formats)")
+	lintCommand.Flags().StringVar(&params.profileOutput, "profile-output", "",
+		"enable profiling and write the profile to the provided file in pprof format, for use with go tool pprof")
 	lintCommand.Flags().BoolVar(&params.instrument, "instrument", false,
 		"enable instrumentation metrics to be added to reporting (currently supported only for JSON output format)")
 
@@ -181,18 +256,27 @@ func lint(args []string, params *lintParams) (result report.Report, err error) {
 	}
 
 	regal := linter.NewLinter().
+		WithPreset(params.preset).
 		WithDisableAll(params.disableAll).
 		WithDisabledCategories(params.disableCategory.v...).
 		WithDisabledRules(params.disable.v...).
 		WithEnableAll(params.enableAll).
 		WithEnabledCategories(params.enableCategory.v...).
 		WithEnabledRules(params.enable.v...).
+		WithDisabledTags(params.disableTag.v...).
+		WithEnabledTags(params.enableTag.v...).
 		WithDebugMode(params.debug).
-		WithProfiling(params.profile).
+		WithProfiling(params.profile || params.profileOutput != "").
 		WithInstrumentation(params.instrument).
-		WithInputPaths(args).
+		WithConcurrency(params.concurrency).
+		WithRuleTimeout(params.ruleTimeout).
+		WithReportUnusedIgnores(params.unusedIgnores).
 		WithBaseCache(cache.NewBaseCache())
 
+	if !params.staged {
+		regal = regal.WithInputPaths(args)
+	}
+
 	if params.enablePrint {
 		regal = regal.WithPrintHook(topdown.NewPrintHook(os.Stderr))
 	}
@@ -205,6 +289,10 @@ func lint(args []string, params *lintParams) (result report.Report, err error) {
 		regal = regal.WithIgnore(params.ignoreFiles.v)
 	}
 
+	if params.cacheDir != "" {
+		regal = regal.WithCacheDir(params.cacheDir)
+	}
+
 	m := metrics.New()
 	if params.metrics {
 		regal = regal.WithMetrics(m)
@@ -248,8 +336,47 @@ func lint(args []string, params *lintParams) (result report.Report, err error) {
 
 	regal = regal.WithUserConfig(userConfig)
 
+	if params.staged {
+		input, err := stagedInput(args, regoVersions(params, regalPath, &userConfig))
+		if err != nil {
+			return report.Report{}, fmt.Errorf("failed to read staged files: %w", err)
+		}
+
+		regal = regal.WithInputModules(&input)
+	}
+
 	go updateCheckAndWarn(params, rbundle.Loaded(), &userConfig)
 
+	filters, err := newViolationFilters(params, searchPath)
+	if err != nil {
+		return report.Report{}, err
+	}
+
+	rep, err := getReporter(params, outputWriter, path)
+	if err != nil {
+		return report.Report{}, fmt.Errorf("failed to get reporter: %w", err)
+	}
+
+	// when reporting in a streaming format, violations are filtered and published per file as
+	// soon as they are found, except for when the baseline is to be updated from the final report
+	ndjson, streaming := rep.(reporter.NDJSONReporter)
+	streaming = streaming && !params.updateBaseline
+
+	var streamed []report.Violation
+
+	if streaming {
+		regal = regal.WithFileResultHandler(func(fr linter.FileResult) error {
+			violations, err := filters.apply(fr.Violations)
+			if err != nil {
+				return err
+			}
+
+			streamed = append(streamed, violations...)
+
+			return ndjson.PublishViolations(violations)
+		})
+	}
+
 	regal, err = regal.Prepare(ctx)
 	if err != nil {
 		return report.Report{}, fmt.Errorf("failed to prepare for linting: %w", err)
@@ -260,14 +387,256 @@ func lint(args []string, params *lintParams) (result report.Report, err error) {
 		return report.Report{}, formatError(params.format, fmt.Errorf("error(s) encountered while linting: %w", err))
 	}
 
-	rep, err := getReporter(params.format, outputWriter)
-	if err != nil {
-		return report.Report{}, fmt.Errorf("failed to get reporter: %w", err)
+	if params.profileOutput != "" {
+		if err = writeProfile(result, params.profileOutput); err != nil {
+			return report.Report{}, err
+		}
+
+		if !params.profile {
+			// only asked for the profile file, so leave it out of the report
+			result.Profile, result.RuleProfile, result.CategoryProfile = nil, nil, nil
+		}
 	}
 
+	if !streaming {
+		if params.updateBaseline {
+			b := baseline.FromViolations(result.Violations)
+			if err = b.Write(params.baseline); err != nil {
+				return report.Report{}, fmt.Errorf("failed to write baseline file %s: %w", params.baseline, err)
+			}
+
+			filters.matcher = baseline.NewMatcher(b)
+		}
+
+		if result.Violations, err = filters.apply(result.Violations); err != nil {
+			return report.Report{}, err
+		}
+	} else {
+		result.Violations = streamed
+	}
+
+	filters.summarize(&result)
+
+	result.Thresholds = warningThresholds(result.Violations, userConfig.Thresholds, params.maxWarnings)
+
 	return result, rep.Publish(ctx, result)
 }
 
+// warningThresholds returns the maximum number of warnings allowed in total, as set by --max-warnings or
+// else the config, followed by those of each category set in the config, along with the number of warnings
+// found for each.
+func warningThresholds(violations []report.Violation, conf *config.Thresholds, maxWarnings int) []report.Threshold {
+	if maxWarnings < 0 && conf != nil && conf.MaxWarnings != nil {
+		maxWarnings = *conf.MaxWarnings
+	}
+
+	var thresholds []report.Threshold
+
+	if maxWarnings >= 0 {
+		thresholds = append(thresholds, report.Threshold{MaxWarnings: maxWarnings})
+	}
+
+	if conf != nil {
+		for _, category := range util.Sorted(slices.Collect(maps.Keys(conf.Categories))) {
+			thresholds = append(thresholds, report.Threshold{
+				Category:    category,
+				MaxWarnings: conf.Categories[category].MaxWarnings,
+			})
+		}
+	}
+
+	for i := range thresholds {
+		for j := range violations {
+			if violations[j].Level == "warning" &&
+				(thresholds[i].Category == "" || thresholds[i].Category == violations[j].Category) {
+				thresholds[i].Warnings++
+			}
+		}
+	}
+
+	return thresholds
+}
+
+// violationFilters removes violations found in a baseline, or not located on lines changed
+// since a base revision, from the violations provided. Violations may be provided all at once,
+// or in batches as they are found.
+type violationFilters struct {
+	matcher  *baseline.Matcher
+	diffBase *git.DiffBase
+}
+
+func newViolationFilters(params *lintParams, searchPath string) (*violationFilters, error) {
+	filters := &violationFilters{}
+
+	if params.baseline != "" && !params.updateBaseline {
+		b, err := baseline.Load(params.baseline)
+		if err != nil {
+			return nil, err
+		}
+
+		filters.matcher = baseline.NewMatcher(b)
+	}
+
+	if params.diffBase != "" {
+		if !rio.IsDir(searchPath) {
+			searchPath = filepath.Dir(searchPath)
+		}
+
+		repoRoot, err := git.FindGitRepo(searchPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine changed lines: %w", err)
+		}
+
+		if repoRoot == "" {
+			return nil, fmt.Errorf("failed to determine changed lines: %s is not in a git repository", searchPath)
+		}
+
+		if filters.diffBase, err = git.NewDiffBase(repoRoot, params.diffBase); err != nil {
+			return nil, fmt.Errorf("failed to determine changed lines: %w", err)
+		}
+	}
+
+	return filters, nil
+}
+
+// apply returns the violations not filtered out by either the baseline or the diff base.
+func (f *violationFilters) apply(violations []report.Violation) ([]report.Violation, error) {
+	if f.matcher != nil {
+		violations = f.matcher.Filter(violations)
+	}
+
+	if f.diffBase != nil {
+		var err error
+		if violations, err = filterToChangedLines(violations, f.diffBase); err != nil {
+			return nil, fmt.Errorf("failed to determine changed lines: %w", err)
+		}
+	}
+
+	return violations, nil
+}
+
+// summarize updates the summary of the report to reflect the filtered violations.
+func (f *violationFilters) summarize(result *report.Report) {
+	if f.matcher == nil && f.diffBase == nil {
+		return
+	}
+
+	result.RecountViolations()
+
+	if f.matcher != nil {
+		result.Summary.BaselineMatched = f.matcher.Matched()
+		result.Summary.BaselineStale = f.matcher.Stale()
+	}
+}
+
+// filterToChangedLines removes violations not located on lines added or modified since
+// the base revision. Note that aggregate rules have still seen the whole workspace, and
+// that violations without a location in any file are kept, as they can't be attributed
+// to a change.
+func filterToChangedLines(violations []report.Violation, base *git.DiffBase) ([]report.Violation, error) {
+	files := util.NewSet[string]()
+
+	for i := range violations {
+		if file := violations[i].Location.File; file != "" {
+			files.Add(file)
+		}
+	}
+
+	if files.Size() == 0 {
+		return violations, nil
+	}
+
+	changed, err := base.ChangedLines(files.Items()...)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Filter(violations, func(v report.Violation) bool {
+		if v.Location.File == "" {
+			return true
+		}
+
+		ranges := changed[v.Location.File]
+		if v.Location.Row == 0 {
+			// violations concerning the whole file are reported if anything in the file changed
+			return len(ranges) > 0
+		}
+
+		end := v.Location.Row
+		if v.Location.End != nil {
+			end = max(end, v.Location.End.Row)
+		}
+
+		return git.Intersects(ranges, v.Location.Row, end)
+	}), nil
+}
+
+// stagedInput creates linter input from the staged contents of the Rego files found in paths,
+// parsed with the Rego versions of versionsMap. The files are enumerated from the git index, and
+// referenced by their paths in the working tree, so files staged but since deleted or renamed
+// on disk are still linted, while files not in the index (i.e. untracked files) are skipped.
+func stagedInput(paths []string, versionsMap map[string]ast.RegoVersion) (rules.Input, error) {
+	if len(paths) == 1 && paths[0] == "-" {
+		return rules.Input{}, errors.New("--staged can't be used when reading from stdin")
+	}
+
+	if len(paths) == 0 {
+		return rules.NewInput(map[string]string{}, map[string]*ast.Module{}), nil
+	}
+
+	dir := paths[0]
+	if !rio.IsDir(dir) {
+		dir = filepath.Dir(dir)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return rules.Input{}, err
+	}
+
+	repoRoot, err := git.FindGitRepo(absDir)
+	if err != nil {
+		return rules.Input{}, err
+	}
+
+	if repoRoot == "" {
+		return rules.Input{}, fmt.Errorf("%s is not in a git repository", dir)
+	}
+
+	staged, err := git.GetStagedContents(repoRoot, paths...)
+	if err != nil {
+		return rules.Input{}, err
+	}
+
+	return rules.InputFromMap(staged, versionsMap)
+}
+
+// regoVersions returns the Rego versions configured for the project, keyed by directory, the
+// same way the linter determines them when reading files from disk.
+func regoVersions(params *lintParams, regalPath string, userConfig *config.Config) map[string]ast.RegoVersion {
+	if regalPath == "" {
+		return nil
+	}
+
+	versionsMap, err := config.AllRegoVersions(regalPath, userConfig)
+	if err != nil && params.debug {
+		log.Printf("failed to get configured Rego versions: %v", err)
+	}
+
+	return versionsMap
+}
+
+// writeProfile writes the profile collected while linting to path, in pprof format.
+func writeProfile(result report.Report, path string) error {
+	if err := rio.WithCreateRecursive(path, func(f *os.File) error {
+		return reporter.WriteProfile(f, result)
+	}); err != nil {
+		return fmt.Errorf("failed to write profile to %s: %w", path, err)
+	}
+
+	return nil
+}
+
 func updateCheckAndWarn(params *lintParams, regalRules *bundle.Bundle, userConfig *config.Config) {
 	mergedConfig, err := config.WithDefaultsFromBundle(regalRules, userConfig)
 	if err != nil {
@@ -289,31 +658,56 @@ func updateCheckAndWarn(params *lintParams, regalRules *bundle.Bundle, userConfi
 	}
 }
 
-func getReporter(format string, outputWriter io.Writer) (reporter.Reporter, error) {
-	switch format {
+func getReporter(params *lintParams, outputWriter io.Writer, configFile string) (reporter.Reporter, error) {
+	switch params.format {
 	case formatPretty:
 		return reporter.NewPrettyReporter(outputWriter), nil
 	case formatCompact:
 		return reporter.NewCompactReporter(outputWriter), nil
 	case formatJSON:
 		return reporter.NewJSONReporter(outputWriter), nil
+	case formatJSONV2:
+		return reporter.NewJSONV2Reporter(outputWriter), nil
 	case formatGitHub:
 		return reporter.NewGitHubReporter(outputWriter), nil
+	case formatGitLab:
+		gitLab := reporter.NewGitLabReporter(outputWriter)
+		if configFile != "" {
+			// paths of violations are relative to the current directory, and so is the config file
+			gitLab = gitLab.WithConfigFile(relativePath(configFile))
+		}
+
+		return gitLab, nil
 	case formatFestive:
 		return reporter.NewFestiveReporter(outputWriter), nil
 	case formatSarif:
 		return reporter.NewSarifReporter(outputWriter), nil
 	case formatJunit:
 		return reporter.NewJUnitReporter(outputWriter), nil
+	case formatCheckstyle:
+		return reporter.NewCheckstyleReporter(outputWriter), nil
+	case formatHTML:
+		return reporter.NewHTMLReporter(outputWriter), nil
+	case formatMarkdown:
+		return reporter.NewMarkdownReporter(outputWriter).WithSizeLimit(params.markdownLimit), nil
+	case formatTemplate:
+		source, err := os.ReadFile(params.templateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+
+		return reporter.NewTemplateReporter(outputWriter, string(source))
+	case formatNDJSON:
+		return reporter.NewNDJSONReporter(outputWriter), nil
 	default:
-		return nil, fmt.Errorf("unknown format %s", format)
+		return nil, fmt.Errorf("unknown format %s", params.format)
 	}
 }
 
 func formatError(format string, err error) error {
-	// currently, JSON and SARIF will get the same generic JSON error format
+	// currently, JSON (both versions) and SARIF will get the same generic JSON error format
 	switch format {
-	case formatJSON, formatSarif:
+	case formatJSON, formatJSONV2, formatSarif:
 		bs, err := json.MarshalIndent(map[string]any{
 			"errors": []string{err.Error()},
 		}, "", "  ")
@@ -343,6 +737,14 @@ func formatError(format string, err error) error {
 			return fmt.Errorf("failed to format errors for output: %w", err)
 		}
 
+		return fmt.Errorf("%s", buf.String())
+	case formatCheckstyle:
+		buf := &bytes.Buffer{}
+
+		if err := reporter.WriteCheckstyleError(buf, err); err != nil {
+			return fmt.Errorf("failed to format errors for output: %w", err)
+		}
+
 		return fmt.Errorf("%s", buf.String())
 	}
 
@@ -67,6 +67,211 @@ func TestLintFileFromStdin(t *testing.T) {
 	testutil.AssertOnlyViolations(t, rep, "opa-fmt", "use-assignment-operator")
 }
 
+func TestLintFailLevelInfoAndHint(t *testing.T) {
+	td := testutil.TempDirectoryOf(t, map[string]string{
+		"config.yaml": `rules:
+  style:
+    opa-fmt:
+      level: hint
+    use-assignment-operator:
+      level: info
+`,
+	})
+
+	for failLevel, exitCode := range map[string]int{"error": 0, "warning": 0, "info": 2, "hint": 2} {
+		t.Run(failLevel, func(t *testing.T) {
+			var rep report.Report
+
+			regal("lint", "-f", "json", "--fail-level", failLevel, "--config-file", filepath.Join(td, "config.yaml"), "-").
+				stdinFrom(strings.NewReader("package p\n\nallow = true")).
+				expectExitCode(exitCode).
+				expectStdout(unmarshalsTo(&rep)).
+				verify(t)
+
+			testutil.AssertOnlyViolations(t, rep, "opa-fmt", "use-assignment-operator")
+		})
+	}
+}
+
+func TestLintTags(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args     []string
+		expected []string
+	}{
+		"enable tag": {
+			args:     []string{"--disable-all", "--enable-tag", "autofixable"},
+			expected: []string{"opa-fmt", "use-assignment-operator"},
+		},
+		"disable tag": {
+			args:     []string{"--disable-tag", "autofixable"},
+			expected: []string{"rule-name-repeats-package"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var rep report.Report
+
+			regal(append([]string{"lint", "-f", "json"}, append(tc.args, "-")...)...).
+				stdinFrom(strings.NewReader("package p\n\np_allow = true")).
+				expectExitCode(3).
+				expectStdout(unmarshalsTo(&rep)).
+				verify(t)
+
+			testutil.AssertOnlyViolations(t, rep, tc.expected...)
+
+			for _, violation := range rep.Violations {
+				autofixable := violation.Title != "rule-name-repeats-package"
+				if slices.Contains(violation.Tags, "autofixable") != autofixable {
+					t.Errorf("unexpected tags %v for %s", violation.Tags, violation.Title)
+				}
+			}
+		})
+	}
+}
+
+func TestLintWarningThresholds(t *testing.T) {
+	td := testutil.TempDirectoryOf(t, map[string]string{
+		"config.yaml": `rules:
+  default:
+    level: warning
+thresholds:
+  categories:
+    style:
+      max-warnings: 1
+`,
+	})
+
+	for name, tc := range map[string]struct {
+		args       []string
+		exitCode   int
+		thresholds []report.Threshold
+	}{
+		"category exceeded": {
+			exitCode:   2,
+			thresholds: []report.Threshold{{Category: "style", MaxWarnings: 1, Warnings: 2}},
+		},
+		"max warnings": {
+			args:     []string{"--max-warnings", "2"},
+			exitCode: 2,
+			thresholds: []report.Threshold{
+				{MaxWarnings: 2, Warnings: 2},
+				{Category: "style", MaxWarnings: 1, Warnings: 2},
+			},
+		},
+		"disabled rule": {
+			args:     []string{"--max-warnings", "5", "--disable", "opa-fmt"},
+			exitCode: 0,
+			thresholds: []report.Threshold{
+				{MaxWarnings: 5, Warnings: 1},
+				{Category: "style", MaxWarnings: 1, Warnings: 1},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var rep report.Report
+
+			args := append([]string{"lint", "-f", "json", "--config-file", filepath.Join(td, "config.yaml")}, tc.args...)
+
+			regal(append(args, "-")...).
+				stdinFrom(strings.NewReader("package p\n\nallow = true")).
+				expectExitCode(tc.exitCode).
+				expectStdout(unmarshalsTo(&rep)).
+				verify(t)
+
+			if !slices.Equal(rep.Thresholds, tc.thresholds) {
+				t.Errorf("expected thresholds %v, got %v", tc.thresholds, rep.Thresholds)
+			}
+		})
+	}
+}
+
+func TestLintJSONV2(t *testing.T) {
+	td := testutil.TempDirectoryOf(t, map[string]string{"p/p.rego": "package p\n\nallow = true\n"})
+
+	var rep struct {
+		Violations []struct {
+			Title          string `json:"title"`
+			Fixable        bool   `json:"fixable"`
+			SuggestedEdits []struct {
+				Text string `json:"text"`
+			} `json:"suggested_edits"`
+		} `json:"violations"`
+		SchemaVersion int `json:"schema_version"`
+	}
+
+	regal("lint", "-f", "json-v2", "--disable", "opa-fmt", filepath.Join(td, "p", "p.rego")).
+		expectExitCode(3).
+		expectStdout(unmarshalsTo(&rep)).
+		verify(t)
+
+	if rep.SchemaVersion != 2 || len(rep.Violations) != 1 {
+		t.Fatalf("expected schema version 2 and a single violation, got %+v", rep)
+	}
+
+	if v := rep.Violations[0]; v.Title != "use-assignment-operator" || !v.Fixable ||
+		len(v.SuggestedEdits) != 1 || v.SuggestedEdits[0].Text != ":" {
+		t.Errorf("unexpected violation %+v", v)
+	}
+}
+
+func TestLintBaseline(t *testing.T) {
+	td := testutil.TempDirectoryOf(t, map[string]string{"p/p.rego": "package p\n\nallow = true\n"})
+	policy := filepath.Join(td, "p", "p.rego")
+	baseline := filepath.Join(td, "baseline.json")
+
+	r := regal("lint", "--disable", "opa-fmt", "--update-baseline", "--baseline", baseline, policy).
+		expectExitCode(0).
+		expectStdout(contains("1 baselined violation suppressed")).
+		verify(t)
+
+	// a new violation, reported on top of the one recorded in the baseline
+	testutil.MustWriteFile(t, policy, []byte("package p\n\nallow = true\n\ndeny = false\n"))
+
+	var rep report.Report
+
+	r.regal("lint", "--format", "json", "--disable", "opa-fmt", "--baseline", baseline, policy).
+		expectExitCode(3).
+		expectStdout(unmarshalsTo(&rep)).
+		verify(t)
+
+	testutil.AssertNumViolations(t, 1, rep)
+
+	if row := rep.Violations[0].Location.Row; row != 5 {
+		t.Errorf("expected the new violation on row 5 to be reported, got row %d", row)
+	}
+
+	if rep.Summary.BaselineMatched != 1 || rep.Summary.BaselineStale != 0 {
+		t.Errorf("expected 1 matched and no stale baseline entries, got %+v", rep.Summary)
+	}
+
+	// violations are filtered per file as they are found when streaming
+	r.regal("lint", "--format", "ndjson", "--disable", "opa-fmt", "--baseline", baseline, policy).
+		expectExitCode(3).
+		expectStdout(contains(`"num_violations":1`), contains(`"baseline_matched":1`)).
+		verify(t)
+}
+
+func TestLintTemplate(t *testing.T) {
+	td := testutil.TempDirectoryOf(t, map[string]string{
+		"report.tmpl": "{{ range .Violations }}{{ .Title }}\n{{ end }}" +
+			"{{ .Summary.NumViolations }} {{ pluralize \"violation\" .Summary.NumViolations }}\n",
+	})
+
+	t.Run("template", func(t *testing.T) {
+		regal("lint", "--format", "template", "--template", filepath.Join(td, "report.tmpl"), "-").
+			stdinFrom(strings.NewReader("package p\n\nallow = true")).
+			expectExitCode(3).
+			expectStdout(equals("opa-fmt\nuse-assignment-operator\n2 violations\n")).
+			verify(t)
+	})
+
+	t.Run("missing template", func(t *testing.T) {
+		regal("lint", "--format", "template", "-").
+			expectExitCode(1).
+			expectStderr(contains("--template must be provided with, and only with, --format template")).
+			verify(t)
+	})
+}
+
 func TestLintNonExistentDir(t *testing.T) {
 	nonexistent := filepath.Join(t.TempDir(), "what", "ever")
 
@@ -80,6 +285,18 @@ func TestLintNonExistentDir(t *testing.T) {
 		verify(t)
 }
 
+func TestLintNonExistentDirCheckstyle(t *testing.T) {
+	nonexistent := filepath.Join(t.TempDir(), "what", "ever")
+
+	regal("lint", "--format", "checkstyle", nonexistent).
+		expectExitCode(1).
+		expectStderr(all(
+			contains(`<checkstyle version="4.3">`),
+			contains(`<error severity="error" message="error(s) encountered while linting`),
+		)).
+		verify(t)
+}
+
 func TestLintProposeToRunFix(t *testing.T) {
 	regal("lint", "--config-file", cwd("e2e_conf.yaml"), cwd("testdata/v0/rule_named_if.rego")).
 		skip(onCondition(!mode.Standalone, "test requires regal to be built with the 'regal_standalone' build ta")).
@@ -349,6 +566,116 @@ func TestMergeRuleConfigWithoutLevel(t *testing.T) {
 		verify(t)
 }
 
+func TestConfigValidate(t *testing.T) {
+	regal("config", "validate", "--config-file", cwd("testdata/configs/invalid.yaml")).
+		expectExitCode(1).
+		expectStdout(
+			contains(`2:3: unknown category "stlye"`),
+			contains(`7:7: unknown attribute "max-line-lenght" for rule "line-length"`),
+		).
+		expectStderr(contains("invalid configuration")).
+		verify(t)
+}
+
+func TestConfigValidateNoProblems(t *testing.T) {
+	regal("config", "validate", "--config-file", cwd("testdata/configs/rule_without_level.yaml")).
+		expectExitCode(0).
+		expectStdout(contains("no problems found")).
+		verify(t)
+}
+
+func TestConfigExplain(t *testing.T) {
+	regal("config", "explain", "file-length", "--config-file", cwd("testdata/configs/rule_without_level.yaml")).
+		expectExitCode(0).
+		expectStdout(
+			contains("style/file-length"),
+			contains("level: error (provided by Regal)"),
+			contains("max-file-length: 1 ("),
+			contains("rule_without_level.yaml)"),
+		).
+		verify(t)
+}
+
+func TestConfigExplainShowsProvidedAttributes(t *testing.T) {
+	configFile := cwd("testdata/configs/override_without_level.yaml")
+
+	r := regal("config", "explain", "line-length", "--config-file", configFile).
+		expectExitCode(0).
+		expectStdout(
+			contains("level: warning ("),
+			contains("max-line-length: 120 (provided by Regal)"),
+			contains("override for tests/**"),
+		).
+		verify(t)
+
+	r.regal("config", "print", "--effective", "--config-file", configFile).
+		expectExitCode(0).
+		expectStdout(contains("override_without_level.yaml\n      max-line-length: 120\n")).
+		verify(t)
+}
+
+func TestConfigPrintOmitsEmptyLevel(t *testing.T) {
+	regal("config", "print", "--config-file", cwd("testdata/configs/override_without_level.yaml")).
+		expectExitCode(0).
+		expectStdout(
+			contains("max-line-length: 150"),
+			notContains(`level: ""`),
+		).
+		verify(t)
+}
+
+func TestConfigPrintEffectiveForFile(t *testing.T) {
+	td := testutil.TempDirectoryOf(t, map[string]string{
+		".regal/config.yaml": `rules:
+  style:
+    line-length:
+      level: warning
+overrides:
+  - files:
+      - "*_test.rego"
+    rules:
+      style:
+        line-length:
+          max-line-length: 150
+`,
+		"p/p.rego":      "package p\n",
+		"p/p_test.rego": "package p_test\n",
+	})
+
+	r := regal("config", "print", "--effective", filepath.Join(td, "p", "p_test.rego")).
+		expectExitCode(0).
+		expectStdout(
+			contains(".regal/config.yaml\n      max-line-length: 150 # "),
+			notContains("overrides:"),
+		).
+		verify(t)
+
+	r.regal("config", "print", "--effective", filepath.Join(td, "p", "p.rego")).
+		expectExitCode(0).
+		expectStdout(
+			contains(".regal/config.yaml\n      max-line-length: 120\n"),
+			notContains("overrides:"),
+		).
+		verify(t)
+}
+
+func TestConfigWithoutConfigFile(t *testing.T) {
+	dir := t.TempDir()
+
+	r := regal("config", "explain", "line-length", dir).
+		expectExitCode(0).
+		expectStdout(
+			contains("level: error (default)"),
+			contains("max-line-length: 120 (default)"),
+		).
+		verify(t)
+
+	r.regal("config", "print", dir).
+		expectExitCode(0).
+		expectStdout(contains("line-length:"), contains("# default")).
+		verify(t)
+}
+
 func TestConfigDefaultingWithDisableDirective(t *testing.T) {
 	regal("lint", "--disable-category=testing", "--config-file", cwd("testdata/configs/defaulting.yaml"),
 		cwd("testdata/defaulting")).
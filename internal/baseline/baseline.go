@@ -0,0 +1,180 @@
+// Package baseline provides means to record the violations of a lint run in a
+// baseline file, and to suppress the violations found in such a file in later runs.
+// This allows enabling new rules in existing projects, while only failing on
+// violations introduced after the baseline was created.
+package baseline
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	rio "github.com/open-policy-agent/regal/internal/io"
+	"github.com/open-policy-agent/regal/pkg/report"
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
+)
+
+// Baseline is the set of violations recorded in a baseline file.
+type Baseline struct {
+	Violations []Entry `json:"violations"`
+}
+
+// Entry is a single violation in the baseline. The fingerprint is what's used for
+// matching, while the other attributes are included to make the file readable.
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	Category    string `json:"category"`
+	Title       string `json:"title"`
+	File        string `json:"file"`
+	Text        string `json:"text,omitempty"`
+	// Count is the number of identical violations, i.e. with the same fingerprint.
+	Count int `json:"count"`
+}
+
+// Matcher suppresses violations found in a baseline, and keeps track of how
+// many violations were matched, and how many entries in the baseline weren't.
+type Matcher struct {
+	recorded  map[string]int
+	remaining map[string]int
+	matched   int
+}
+
+// FromViolations creates a baseline from the provided violations.
+func FromViolations(violations []report.Violation) Baseline {
+	entries := make(map[string]*Entry, len(violations))
+
+	for _, v := range violations { //nolint:gocritic
+		v = Normalize(v)
+		fp := v.Fingerprint()
+
+		if e, ok := entries[fp]; ok {
+			e.Count++
+
+			continue
+		}
+
+		e := &Entry{Fingerprint: fp, Category: v.Category, Title: v.Title, File: v.Location.File, Count: 1}
+		if v.Location.Text != nil {
+			e.Text = strings.TrimSpace(*v.Location.Text)
+		}
+
+		entries[fp] = e
+	}
+
+	b := Baseline{Violations: make([]Entry, 0, len(entries))}
+	for _, e := range entries {
+		b.Violations = append(b.Violations, *e)
+	}
+
+	slices.SortFunc(b.Violations, func(a, b Entry) int {
+		return cmp.Or(
+			strings.Compare(a.File, b.File),
+			strings.Compare(a.Category, b.Category),
+			strings.Compare(a.Title, b.Title),
+			strings.Compare(a.Fingerprint, b.Fingerprint),
+		)
+	})
+
+	return b
+}
+
+// Load reads a baseline from the file at path.
+func Load(path string) (Baseline, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return Baseline{}, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	b, err := encoding.JSONUnmarshalTo[Baseline](bs)
+	if err != nil {
+		return Baseline{}, fmt.Errorf("failed to decode baseline file %s: %w", path, err)
+	}
+
+	return b, nil
+}
+
+// Write writes the baseline to the file at path, creating any missing parent directories.
+func (b Baseline) Write(path string) error {
+	return rio.WithCreateRecursive(path, func(f *os.File) error {
+		enc := encoding.JSON().NewEncoder(f)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(b)
+	})
+}
+
+// NewMatcher creates a new Matcher for the baseline.
+func NewMatcher(b Baseline) *Matcher {
+	recorded := make(map[string]int, len(b.Violations))
+	for _, e := range b.Violations {
+		recorded[e.Fingerprint] += max(e.Count, 1)
+	}
+
+	return &Matcher{recorded: recorded, remaining: maps.Clone(recorded)}
+}
+
+// Filter returns the violations not found in the baseline. Each entry in the baseline
+// is only matched as many times as it was recorded, so that adding another violation
+// identical to one already in the baseline is still reported.
+func (m *Matcher) Filter(violations []report.Violation) []report.Violation {
+	result := make([]report.Violation, 0, len(violations))
+
+	for i := range violations {
+		fp := Normalize(violations[i]).Fingerprint()
+		if m.remaining[fp] > 0 {
+			m.remaining[fp]--
+			m.matched++
+
+			continue
+		}
+
+		result = append(result, violations[i])
+	}
+
+	return result
+}
+
+// Matched returns the number of violations suppressed by the baseline.
+func (m *Matcher) Matched() int {
+	return m.matched
+}
+
+// Stale returns the number of entries in the baseline that matched no violation,
+// which likely means they have since been fixed.
+func (m *Matcher) Stale() int {
+	stale := 0
+
+	for fp, n := range m.recorded {
+		if m.remaining[fp] == n {
+			stale++
+		}
+	}
+
+	return stale
+}
+
+// Normalize returns a copy of the violation with the file path made relative to the
+// current working directory, and using forward slashes, so that the fingerprint
+// doesn't depend on how the linted paths were provided.
+func Normalize(v report.Violation) report.Violation {
+	file := v.Location.File
+	if file == "" {
+		return v
+	}
+
+	if filepath.IsAbs(file) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+	}
+
+	v.Location.File = filepath.ToSlash(filepath.Clean(file))
+
+	return v
+}
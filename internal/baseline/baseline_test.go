@@ -0,0 +1,106 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+func violation(title, file string, row int, text string) report.Violation {
+	return report.Violation{
+		Title:    title,
+		Category: "style",
+		Location: report.Location{File: file, Row: row, Column: 1, Text: util.Pointer(text)},
+	}
+}
+
+func TestMatcherIgnoresRowsAndWhitespace(t *testing.T) {
+	t.Parallel()
+
+	b := FromViolations([]report.Violation{
+		violation("prefer-snake-case", "p.rego", 3, "camelCase := 1"),
+		violation("line-length", "p.rego", 10, "x := 1"),
+	})
+
+	m := NewMatcher(b)
+	remaining := m.Filter([]report.Violation{
+		// moved down, and indented differently
+		violation("prefer-snake-case", "p.rego", 7, "   camelCase   := 1"),
+		// new violation
+		violation("prefer-snake-case", "p.rego", 8, "otherCase := 1"),
+	})
+
+	if len(remaining) != 1 || remaining[0].Location.Row != 8 {
+		t.Errorf("expected only the new violation to be reported, got %v", remaining)
+	}
+
+	if matched := m.Matched(); matched != 1 {
+		t.Errorf("expected 1 matched baseline violation, got %d", matched)
+	}
+
+	if stale := m.Stale(); stale != 1 {
+		t.Errorf("expected 1 stale baseline entry, got %d", stale)
+	}
+}
+
+func TestMatcherFiltersViolationsInBatches(t *testing.T) {
+	t.Parallel()
+
+	v := violation("todo-comment", "p.rego", 1, "# TODO")
+	m := NewMatcher(FromViolations([]report.Violation{v, v}))
+
+	// violations are filtered per file as they are found when streaming results,
+	// so the baseline entries matched by earlier batches must not be matched again
+	first := m.Filter([]report.Violation{v})
+	second := m.Filter([]report.Violation{v, v})
+
+	if len(first) != 0 || len(second) != 1 {
+		t.Errorf("expected 1 violation not in baseline, got %d and %d", len(first), len(second))
+	}
+
+	if matched := m.Matched(); matched != 2 {
+		t.Errorf("expected 2 matched baseline violations, got %d", matched)
+	}
+}
+
+func TestMatcherCountsIdenticalViolations(t *testing.T) {
+	t.Parallel()
+
+	v := violation("todo-comment", "p.rego", 1, "# TODO")
+	m := NewMatcher(FromViolations([]report.Violation{v, v}))
+
+	if remaining := m.Filter([]report.Violation{v, v, v}); len(remaining) != 1 {
+		t.Errorf("expected 1 violation not in baseline, got %d", len(remaining))
+	}
+}
+
+func TestMatcherCountsStaleEntries(t *testing.T) {
+	t.Parallel()
+
+	fixed := violation("todo-comment", "p.rego", 1, "# TODO")
+	partly := violation("todo-comment", "p.rego", 2, "# TODO: later")
+
+	m := NewMatcher(FromViolations([]report.Violation{fixed, fixed, fixed, partly, partly}))
+	m.Filter([]report.Violation{partly})
+
+	if stale := m.Stale(); stale != 1 {
+		t.Errorf("expected 1 stale baseline entry, got %d", stale)
+	}
+}
+
+func TestWriteAndLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".regal", "baseline.json")
+	b := FromViolations([]report.Violation{violation("todo-comment", "b.rego", 1, "# TODO")})
+
+	testutil.NoErr(b.Write(path))(t)
+
+	loaded := testutil.Must(Load(path))(t)
+	if len(loaded.Violations) != 1 || loaded.Violations[0] != b.Violations[0] {
+		t.Errorf("expected loaded baseline to equal written baseline, got %v", loaded)
+	}
+}
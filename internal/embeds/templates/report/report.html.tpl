@@ -0,0 +1,104 @@
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Regal Lint Report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0 auto; max-width: 1100px; padding: 2em; color: #1f2328; }
+h1 { margin-top: 0; }
+h2 { border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+a { color: #0969da; }
+.cards { display: flex; flex-wrap: wrap; gap: 1em; margin-bottom: 2em; }
+.card { border: 1px solid #d0d7de; border-radius: 6px; padding: 1em 1.5em; min-width: 8em; }
+.card .value { font-size: 2em; font-weight: 600; }
+.card .label { color: #59636e; }
+.charts { display: flex; flex-wrap: wrap; gap: 2em; margin-bottom: 2em; }
+.chart { flex: 1; min-width: 20em; }
+.bar-row { display: flex; align-items: center; gap: .5em; margin: .3em 0; }
+.bar-label { width: 10em; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+.bar-track { flex: 1; background: #f6f8fa; border-radius: 3px; }
+.bar { background: #0969da; height: 1.2em; border-radius: 3px; min-width: 2px; }
+.bar-count { width: 3em; text-align: right; }
+details { border: 1px solid #d0d7de; border-radius: 6px; margin: .5em 0; padding: .5em 1em; }
+summary { cursor: pointer; font-weight: 600; }
+.category { margin: .5em 0 .5em 1em; }
+.rule { margin: .5em 0 1em 1em; }
+.rule h4 { margin: .5em 0 .2em; }
+.description { color: #59636e; margin: 0 0 .5em; }
+.violation { margin: .3em 0; }
+.level { border-radius: 2em; font-size: .8em; padding: .1em .6em; color: #fff; }
+.level-error { background: #cf222e; }
+.level-warning { background: #9a6700; }
+.level-info, .level-hint { background: #0969da; }
+pre { background: #f6f8fa; border-radius: 6px; margin: .3em 0; overflow-x: auto; padding: .5em 1em; }
+pre .row { color: #59636e; user-select: none; }
+mark { background: #ffebe9; border-bottom: 2px solid #cf222e; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #d0d7de; padding: .3em .8em; }
+td.number { text-align: right; }
+</style>
+</head>
+<body>
+<h1>Regal Lint Report</h1>
+
+<div class="cards">
+<div class="card"><div class="value">{{ .Summary.FilesScanned }}</div><div class="label">files linted</div></div>
+<div class="card"><div class="value">{{ .Summary.FilesFailed }}</div><div class="label">files with violations</div></div>
+<div class="card"><div class="value">{{ .Summary.NumViolations }}</div><div class="label">violations</div></div>
+<div class="card"><div class="value">{{ .Summary.RulesSkipped }}</div><div class="label">rules skipped</div></div>
+</div>
+{{- if .Charts }}
+
+<div class="charts">
+{{- range .Charts }}
+<div class="chart">
+<h3>{{ .Title }}</h3>
+{{- range .Bars }}
+<div class="bar-row"><span class="bar-label" title="{{ .Label }}">{{ .Label }}</span><div class="bar-track"><div class="bar" style="width: {{ .Percent }}%"></div></div><span class="bar-count">{{ .Count }}</span></div>
+{{- end }}
+</div>
+{{- end }}
+</div>
+{{- end }}
+
+<h2>Violations</h2>
+{{- range .Files }}
+<details open>
+<summary>{{ .Name }} ({{ .Count }})</summary>
+{{- range .Categories }}
+<div class="category">
+<h3>{{ .Name }}</h3>
+{{- range .Rules }}
+<div class="rule">
+<h4>{{ .Title }}</h4>
+<p class="description">{{ .Description }}
+{{- range .RelatedResources }} <a href="{{ .Reference }}">{{ .Description }}</a>{{ end }}</p>
+{{- range .Violations }}
+<div class="violation">
+<span class="level level-{{ .Level }}">{{ .Level }}</span> {{ .Location }}
+{{- with .Excerpt }}
+<pre><span class="row">{{ .Row }} </span>{{ .Before }}<mark>{{ .Highlight }}</mark>{{ .After }}</pre>
+{{- end }}
+</div>
+{{- end }}
+</div>
+{{- end }}
+</div>
+{{- end }}
+</details>
+{{- else }}
+<p>No violations found.</p>
+{{- end }}
+{{- if .RuleProfile }}
+
+<h2>Profile</h2>
+<table>
+<tr><th>Rule</th><th>Time</th><th>Evals</th><th>Violations</th></tr>
+{{- range .RuleProfile }}
+<tr><td>{{ .Name }}</td><td class="number">{{ .Time }}</td><td class="number">{{ .NumEval }}</td><td class="number">{{ .NumViolations }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
@@ -0,0 +1,166 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// LineRange is an inclusive range of lines, starting from 1.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// Intersects returns true if the range [start, end] overlaps with any of the line ranges.
+func Intersects(ranges []LineRange, start, end int) bool {
+	for _, r := range ranges {
+		if start <= r.End && end >= r.Start {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DiffBase is the revision that changes are determined against, resolved once so that
+// changed lines may be looked up for any number of files without reading the repository
+// again.
+type DiffBase struct {
+	root string
+	name string
+	tree *object.Tree
+}
+
+// NewDiffBase resolves the base revision (like a branch name, tag or commit) in the repository
+// at repoRoot. Changes are determined against the merge base of that revision and HEAD, i.e.
+// the commit where the current branch forked off, so that changes made on the base branch after
+// that point aren't considered changes of the current branch.
+func NewDiffBase(repoRoot, base string) (*DiffBase, error) {
+	repo, err := git.PlainOpen(repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	baseCommit, err := commitOf(repo, base)
+	if err != nil {
+		return nil, err
+	}
+
+	headCommit, err := commitOf(repo, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	mergeBases, err := headCommit.MergeBase(baseCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base of %s and HEAD: %w", base, err)
+	}
+
+	if len(mergeBases) == 0 {
+		return nil, fmt.Errorf("%s and HEAD have no common ancestor", base)
+	}
+
+	tree, err := mergeBases[0].Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree of commit %s: %w", mergeBases[0].Hash, err)
+	}
+
+	absRoot, err := filepath.Abs(repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path of %s: %w", repoRoot, err)
+	}
+
+	return &DiffBase{root: absRoot, name: base, tree: tree}, nil
+}
+
+func commitOf(repo *git.Repository, revision string) (*object.Commit, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve revision %s: %w", revision, err)
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", hash, err)
+	}
+
+	return commit, nil
+}
+
+// ChangedLines returns the lines added or modified in each of the provided files, compared
+// to the version of the same file at the base revision. Files not present at the base revision
+// are considered to have all of their lines added. Files without changes are included with no
+// line ranges. The returned map is keyed by the paths as provided.
+func (b *DiffBase) ChangedLines(files ...string) (map[string][]LineRange, error) {
+	changed := make(map[string][]LineRange, len(files))
+
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absolute path of %s: %w", file, err)
+		}
+
+		rel, err := filepath.Rel(b.root, abs)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("file %s is not in repository %s", file, b.root)
+		}
+
+		current, err := os.ReadFile(abs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		var previous string
+
+		switch f, err := b.tree.File(filepath.ToSlash(rel)); {
+		case errors.Is(err, object.ErrFileNotFound):
+			// new file, so everything is added
+		case err != nil:
+			return nil, fmt.Errorf("failed to get %s at %s: %w", file, b.name, err)
+		default:
+			if previous, err = f.Contents(); err != nil {
+				return nil, fmt.Errorf("failed to read %s at %s: %w", file, b.name, err)
+			}
+		}
+
+		changed[file] = addedLines(previous, string(current))
+	}
+
+	return changed, nil
+}
+
+// addedLines returns the ranges of lines in dst that were added or modified compared to src.
+func addedLines(src, dst string) []LineRange {
+	var (
+		ranges []LineRange
+		line   = 1
+	)
+
+	for _, d := range diff.Do(src, dst) {
+		n := strings.Count(d.Text, "\n")
+		if !strings.HasSuffix(d.Text, "\n") {
+			n++
+		}
+
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			line += n
+		case diffmatchpatch.DiffInsert:
+			ranges = append(ranges, LineRange{Start: line, End: line + n - 1})
+			line += n
+		case diffmatchpatch.DiffDelete:
+			// removed lines don't exist in dst, so there's nothing to report on
+		}
+	}
+
+	return ranges
+}
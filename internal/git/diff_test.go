@@ -0,0 +1,127 @@
+package git
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestAddedLines(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		src, dst string
+		expected []LineRange
+	}{
+		{name: "unchanged", src: "a\nb\nc\n", dst: "a\nb\nc\n"},
+		{name: "new file", src: "", dst: "a\nb\n", expected: []LineRange{{1, 2}}},
+		{name: "line added", src: "a\nc\n", dst: "a\nb\nc\n", expected: []LineRange{{2, 2}}},
+		{name: "line modified", src: "a\nb\nc\n", dst: "a\nx\nc\n", expected: []LineRange{{2, 2}}},
+		{name: "line removed", src: "a\nb\nc\n", dst: "a\nc\n"},
+		{name: "multiple hunks", src: "a\nb\nc\nd\n", dst: "x\na\nb\nc\ny\nz\n", expected: []LineRange{{1, 1}, {5, 6}}},
+		{name: "no trailing newline", src: "a\n", dst: "a\nb", expected: []LineRange{{2, 2}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := addedLines(tc.src, tc.dst); !slices.Equal(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestChangedLines(t *testing.T) {
+	t.Parallel()
+
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"p/p.rego": "package p\n\nallow := true\n",
+	})
+
+	repo := testutil.Must(git.PlainInit(root, false))(t)
+	wt := testutil.Must(repo.Worktree())(t)
+
+	testutil.Must(wt.Add("p/p.rego"))(t)
+	testutil.Must(wt.Commit("initial", &git.CommitOptions{
+		Author: &object.Signature{Name: "regal", Email: "regal@example.com", When: time.Now()},
+	}))(t)
+
+	modified := filepath.Join(root, "p", "p.rego")
+	added := filepath.Join(root, "q", "q.rego")
+
+	testutil.MustWriteFile(t, modified, []byte("package p\n\nallow := true\n\ndeny := false\n"))
+	testutil.MustMkdirAll(t, filepath.Dir(added))
+	testutil.MustWriteFile(t, added, []byte("package q\n\nx := 1\n"))
+
+	changed := testutil.Must(testutil.Must(NewDiffBase(root, "HEAD"))(t).ChangedLines(modified, added))(t)
+
+	if exp := []LineRange{{4, 5}}; !slices.Equal(changed[modified], exp) {
+		t.Errorf("expected %v for modified file, got %v", exp, changed[modified])
+	}
+
+	if exp := []LineRange{{1, 3}}; !slices.Equal(changed[added], exp) {
+		t.Errorf("expected %v for added file, got %v", exp, changed[added])
+	}
+
+	if !Intersects(changed[modified], 5, 5) || Intersects(changed[modified], 1, 3) {
+		t.Errorf("unexpected intersection result for %v", changed[modified])
+	}
+
+	if _, err := NewDiffBase(root, "no-such-branch"); err == nil {
+		t.Error("expected error for unknown revision")
+	}
+}
+
+func TestChangedLinesSinceMergeBase(t *testing.T) {
+	t.Parallel()
+
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"p.rego": "package p\n\nallow := true\n",
+		"q.rego": "package q\n\nx := 1\n",
+	})
+
+	repo := testutil.Must(git.PlainInit(root, false))(t)
+	wt := testutil.Must(repo.Worktree())(t)
+	commit := func(msg string, files ...string) plumbing.Hash {
+		for _, file := range files {
+			testutil.Must(wt.Add(file))(t)
+		}
+
+		return testutil.Must(wt.Commit(msg, &git.CommitOptions{
+			Author: &object.Signature{Name: "regal", Email: "regal@example.com", When: time.Now()},
+		}))(t)
+	}
+
+	forkPoint := commit("initial", "p.rego", "q.rego")
+
+	// the base branch moves on after the current branch forked off
+	testutil.MustWriteFile(t, filepath.Join(root, "q.rego"), []byte("package q\n\nx := 2\n"))
+
+	main := commit("change on main", "q.rego")
+	testutil.NoErr(repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/main", main)))(t)
+	testutil.NoErr(wt.Reset(&git.ResetOptions{Commit: forkPoint, Mode: git.HardReset}))(t)
+
+	testutil.MustWriteFile(t, filepath.Join(root, "p.rego"), []byte("package p\n\nallow := true\n\ndeny := false\n"))
+	commit("change on branch", "p.rego")
+
+	p, q := filepath.Join(root, "p.rego"), filepath.Join(root, "q.rego")
+	changed := testutil.Must(testutil.Must(NewDiffBase(root, "main"))(t).ChangedLines(p, q))(t)
+
+	if exp := []LineRange{{4, 5}}; !slices.Equal(changed[p], exp) {
+		t.Errorf("expected %v for file changed on branch, got %v", exp, changed[p])
+	}
+
+	if len(changed[q]) != 0 {
+		t.Errorf("expected no changes for file only changed on main, got %v", changed[q])
+	}
+}
@@ -3,10 +3,13 @@ package git
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 
 	"github.com/open-policy-agent/opa/v1/util"
 )
@@ -81,3 +84,89 @@ func GetChangedFiles(dir string) ([]string, error) {
 
 	return util.Keys(status), nil
 }
+
+// GetStagedContents returns the contents of the Rego files in the index of the repository at
+// repoRoot, i.e. what would be committed, rather than what's on disk. Only files at, or in
+// directories under, the provided paths are included. The files are enumerated from the index,
+// so files staged but since deleted or renamed on disk are included too, while files not in the
+// index, like untracked files or files staged for removal, are not. The returned map is keyed by
+// the paths of the files as joined with the provided paths, e.g. "bundle/p.rego" for "bundle".
+func GetStagedContents(repoRoot string, paths ...string) (map[string]string, error) {
+	repo, err := git.PlainOpen(repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	absRoot, err := filepath.Abs(repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path of %s: %w", repoRoot, err)
+	}
+
+	contents := make(map[string]string)
+
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absolute path of %s: %w", path, err)
+		}
+
+		rel, err := filepath.Rel(absRoot, abs)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("path %s is not in repository %s", path, repoRoot)
+		}
+
+		rel = filepath.ToSlash(rel)
+
+		for _, entry := range idx.Entries {
+			// files with unresolved conflicts have an entry for each side, rather than one at stage 0
+			if entry.Stage != 0 || !strings.HasSuffix(entry.Name, ".rego") {
+				continue
+			}
+
+			var file string
+
+			switch {
+			case entry.Name == rel:
+				file = path
+			case rel == ".":
+				file = filepath.Join(path, filepath.FromSlash(entry.Name))
+			case strings.HasPrefix(entry.Name, rel+"/"):
+				file = filepath.Join(path, filepath.FromSlash(strings.TrimPrefix(entry.Name, rel+"/")))
+			default:
+				continue
+			}
+
+			if contents[file], err = blobContents(repo, entry.Hash); err != nil {
+				return nil, fmt.Errorf("failed to read staged blob of %s: %w", file, err)
+			}
+		}
+	}
+
+	return contents, nil
+}
+
+func blobContents(repo *git.Repository, hash plumbing.Hash) (string, error) {
+	blob, err := repo.BlobObject(hash)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := blob.Reader()
+	if err != nil {
+		return "", err
+	}
+
+	defer r.Close()
+
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bs), nil
+}
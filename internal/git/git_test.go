@@ -0,0 +1,75 @@
+package git
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestGetStagedContents(t *testing.T) {
+	t.Parallel()
+
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"staged.rego":    "package staged\n",
+		"untracked.rego": "package untracked\n",
+	})
+
+	repo := testutil.Must(git.PlainInit(root, false))(t)
+	wt := testutil.Must(repo.Worktree())(t)
+
+	testutil.Must(wt.Add("staged.rego"))(t)
+
+	staged := filepath.Join(root, "staged.rego")
+	untracked := filepath.Join(root, "untracked.rego")
+
+	// modify the file after staging, which should not be reflected in the result
+	testutil.MustWriteFile(t, staged, []byte("package modified\n"))
+
+	contents := testutil.Must(GetStagedContents(root, staged, untracked))(t)
+
+	if len(contents) != 1 {
+		t.Fatalf("expected 1 staged file, got %d", len(contents))
+	}
+
+	if contents[staged] != "package staged\n" {
+		t.Errorf("expected staged contents, got %q", contents[staged])
+	}
+}
+
+func TestGetStagedContentsEnumeratesIndex(t *testing.T) {
+	t.Parallel()
+
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"bundle/p.rego":       "package p\n",
+		"bundle/sub/q.rego":   "package q\n",
+		"bundle/data.json":    "{}",
+		"other/r.rego":        "package r\n",
+		"bundle/deleted.rego": "package deleted\n",
+	})
+
+	repo := testutil.Must(git.PlainInit(root, false))(t)
+	wt := testutil.Must(repo.Worktree())(t)
+
+	testutil.NoErr(wt.AddGlob("."))(t)
+
+	// staged, but since deleted on disk, which should still be linted
+	testutil.NoErr(os.Remove(filepath.Join(root, "bundle", "deleted.rego")))(t)
+
+	dir := filepath.Join(root, "bundle")
+	contents := testutil.Must(GetStagedContents(root, dir))(t)
+
+	expected := map[string]string{
+		filepath.Join(dir, "p.rego"):        "package p\n",
+		filepath.Join(dir, "sub", "q.rego"): "package q\n",
+		filepath.Join(dir, "deleted.rego"):  "package deleted\n",
+	}
+
+	if !maps.Equal(contents, expected) {
+		t.Errorf("expected %v, got %v", expected, contents)
+	}
+}
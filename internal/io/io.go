@@ -251,7 +251,7 @@ func ModulesFromCustomRuleFS(customRuleFS fs.FS, rootPath string) (map[string]*a
 				return modules, fmt.Errorf("failed to read custom rule file: %w", err)
 			}
 
-			m, err := ast.ParseModule(path, outil.ByteSliceToString(bs))
+			m, err := ast.ParseModuleWithOpts(path, outil.ByteSliceToString(bs), ast.ParserOptions{ProcessAnnotation: true})
 			if err != nil {
 				return modules, fmt.Errorf("failed to parse custom rule file %q: %w", path, err)
 			}
@@ -49,7 +49,7 @@ func TestHandleTextDocumentCodeAction(t *testing.T) {
 		Kind:        "quickfix",
 		Diagnostics: params.Context.Diagnostics,
 		IsPreferred: util.Pointer(true),
-		Command: types.Command{
+		Command: &types.Command{
 			Title:   "Replace = with := in assignment",
 			Command: "regal.fix.use-assignment-operator",
 			Tooltip: "Replace = with := in assignment",
@@ -99,7 +99,7 @@ func TestHandleTextDocumentCodeActionSourceExplorer(t *testing.T) {
 	expectedAction := types.CodeAction{
 		Title: "Explore compiler stages for this policy",
 		Kind:  "source.explore",
-		Command: types.Command{
+		Command: &types.Command{
 			Title:     "Explore compiler stages for this policy",
 			Command:   "vscode.open",
 			Tooltip:   "Explore compiler stages for this policy",
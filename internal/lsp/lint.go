@@ -34,6 +34,7 @@ var (
 	diagErrorLevel *uint = util.Pointer(uint(1))
 	diagWarnLevel  *uint = util.Pointer(uint(2))
 	diagInfoLevel  *uint = util.Pointer(uint(3))
+	diagHintLevel  *uint = util.Pointer(uint(4))
 )
 
 // diagnosticsRunOpts contains options for file and workspace linting.
@@ -302,8 +303,12 @@ func convertReportToDiagnostics(rpt *report.Report, workspaceRootURI string) map
 		// here errors are presented as warnings, and warnings as info
 		// to differentiate from parse errors
 		severity := diagWarnLevel
-		if item.Level == "warning" {
+
+		switch item.Level {
+		case "warning", "info":
 			severity = diagInfoLevel
+		case "hint":
+			severity = diagHintLevel
 		}
 
 		file := cmp.Or(item.Location.File, workspaceRootURI)
@@ -199,6 +199,26 @@ func TestConvertReportToDiagnostics(t *testing.T) {
 	}
 }
 
+func TestConvertReportToDiagnosticsSeverities(t *testing.T) {
+	t.Parallel()
+
+	expected := map[string]uint{"error": 2, "warning": 3, "info": 3, "hint": 4}
+
+	for level, severity := range expected {
+		rpt := &report.Report{Violations: []report.Violation{{
+			Title:    "mock_title",
+			Category: "mock_category",
+			Level:    level,
+			Location: report.Location{File: "file1", Row: 1, Column: 1},
+		}}}
+
+		diags := convertReportToDiagnostics(rpt, "workspaceRootURI")["file1"]
+		if len(diags) != 1 || *diags[0].Severity != severity {
+			t.Errorf("expected severity %d for level %s, got %v", severity, level, diags)
+		}
+	}
+}
+
 func TestLintWithConfigIgnoreWildcards(t *testing.T) {
 	t.Parallel()
 
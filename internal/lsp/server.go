@@ -1097,7 +1097,17 @@ func (l *LanguageServer) fixRenameParams(label, fileURI string) (types.ApplyWork
 	violations := []report.Violation{{Title: fix.Name(), Location: report.Location{File: uri.ToPath(fileURI)}}}
 	cfprovider := fileprovider.NewCacheFileProvider(l.cache, l.client.Identifier)
 
-	fixReport, err := f.FixViolations(violations, cfprovider, l.getLoadedConfig())
+	conf := l.getLoadedConfig()
+	if conf != nil {
+		pathConf, err := conf.ForPath(uri.ToPath(fileURI), l.workspacePath())
+		if err != nil {
+			return types.ApplyWorkspaceAnyEditParams{}, fmt.Errorf("failed to get config for file: %w", err)
+		}
+
+		conf = &pathConf
+	}
+
+	fixReport, err := f.FixViolations(violations, cfprovider, conf)
 	if err != nil {
 		return types.ApplyWorkspaceAnyEditParams{}, fmt.Errorf("failed to fix violations: %w", err)
 	}
@@ -168,11 +168,12 @@ type (
 	}
 
 	CodeAction struct {
-		Command     Command      `json:"command"`
-		IsPreferred *bool        `json:"isPreferred,omitempty"`
-		Title       string       `json:"title"`
-		Kind        string       `json:"kind"`
-		Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+		Command     *Command       `json:"command,omitempty"`
+		Edit        *WorkspaceEdit `json:"edit,omitempty"`
+		IsPreferred *bool          `json:"isPreferred,omitempty"`
+		Title       string         `json:"title"`
+		Kind        string         `json:"kind"`
+		Diagnostics []Diagnostic   `json:"diagnostics,omitempty"`
 	}
 
 	CodeLens struct {
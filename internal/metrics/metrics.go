@@ -17,6 +17,8 @@ const (
 	RegalLintRego             = "regal_lint_rego"
 	RegalLintRegoAggregate    = "regal_lint_rego_aggregate"
 	RegalMergeReport          = "regal_assemble_report"
+	RegalLintCacheHit         = "regal_lint_cache_hit"
+	RegalLintCacheMiss        = "regal_lint_cache_miss"
 )
 
 func FromExprStats(stats profiler.ExprStats) report.ProfileEntry {
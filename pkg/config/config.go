@@ -27,6 +27,7 @@ const (
 	capabilitiesEngineEOPA   = "eopa"
 	keyIgnore                = "ignore"
 	keyLevel                 = "level"
+	keyMaxWarnings           = "max-warnings"
 	regalDirName             = ".regal"
 	configFileName           = "config.yaml"
 	standaloneConfigFileName = ".regal.yaml"
@@ -42,6 +43,16 @@ type Config struct {
 	Project         *Project            `json:"project,omitempty"          yaml:"project,omitempty"`
 	CapabilitiesURL string              `json:"capabilities_url,omitempty" yaml:"capabilities_url,omitempty"`
 	Ignore          Ignore              `json:"ignore"                     yaml:"ignore"`
+	Overrides       []Override          `json:"overrides,omitempty"        yaml:"overrides,omitempty"`
+	Thresholds      *Thresholds         `json:"thresholds,omitempty"       yaml:"thresholds,omitempty"`
+	// Extends holds the paths of configuration files this configuration is merged on top of. It's
+	// only set until resolved by FromFile, or when the configuration is decoded by other means.
+	Extends []string `json:"extends,omitempty" yaml:"extends,omitempty"`
+	// Presets holds the names of the presets applied to this configuration, in the order applied.
+	Presets []string `json:"-" yaml:"-"`
+	// Sources holds the source of each setting, when the configuration was loaded by FromFile
+	// or had a preset applied.
+	Sources Sources `json:"-" yaml:"-"`
 }
 
 type Root struct {
@@ -58,6 +69,18 @@ type Project struct {
 	RegoVersion *int `json:"rego-version,omitempty" yaml:"rego-version,omitempty"`
 }
 
+// Thresholds sets the maximum number of warnings allowed in total, and per category, before
+// the lint command exits with a non-zero exit code.
+type Thresholds struct {
+	MaxWarnings *int                         `json:"max-warnings,omitempty" yaml:"max-warnings,omitempty"`
+	Categories  map[string]CategoryThreshold `json:"categories,omitempty"   yaml:"categories,omitempty"`
+}
+
+// CategoryThreshold sets the maximum number of warnings allowed in a category.
+type CategoryThreshold struct {
+	MaxWarnings int `json:"max-warnings" yaml:"max-warnings"`
+}
+
 type Category map[string]Rule
 
 // Defaults is used to store information about global and category
@@ -126,9 +149,10 @@ func FromPath(path string) (Config, error) {
 	return util.Wrap(rio.WithOpen(path, FromFile))("failed to open config file")
 }
 
+// FromFile decodes the configuration in file, merged on top of the configuration of any files it
+// extends. See FromFileWithSources for also getting the file each setting was set in.
 func FromFile(file *os.File) (Config, error) {
-	conf := Config{}
-	err := yaml.NewDecoder(file).Decode(&conf)
+	conf, _, err := FromFileWithSources(file)
 
 	return conf, err
 }
@@ -482,8 +506,11 @@ type marshallingIntermediary struct {
 			} `yaml:"builtins"`
 		} `yaml:"minus"`
 	} `yaml:"capabilities"`
-	Ignore   Ignore `yaml:"ignore"`
-	Features struct {
+	Ignore     Ignore      `yaml:"ignore"`
+	Overrides  []Override  `yaml:"overrides"`
+	Thresholds *Thresholds `yaml:"thresholds"`
+	Extends    []string    `yaml:"extends"`
+	Features   struct {
 		RemoteFeatures struct {
 			CheckVersion bool `yaml:"check-version"`
 		} `yaml:"remote"`
@@ -530,6 +557,16 @@ func (config *Config) UnmarshalYAML(value *yaml.Node) error {
 
 	config.Ignore = result.Ignore
 
+	for i, override := range result.Overrides {
+		if len(override.Files) == 0 {
+			return fmt.Errorf("override %d must provide at least one pattern in files", i)
+		}
+	}
+
+	config.Overrides = result.Overrides
+	config.Thresholds = result.Thresholds
+	config.Extends = result.Extends
+
 	capabilitiesFile := result.Capabilities.From.File
 	capabilitiesEngine := result.Capabilities.From.Engine
 	capabilitiesEngineVersion := result.Capabilities.From.Version
@@ -772,7 +809,12 @@ func (rule *Rule) UnmarshalJSON(data []byte) error {
 }
 
 func (rule *Rule) MarshalYAML() (any, error) {
-	result := map[string]any{keyLevel: rule.Level}
+	result := make(map[string]any, len(rule.Extra)+2)
+
+	// a rule in an override may not set a level, in which case it's inherited
+	if rule.Level != "" {
+		result[keyLevel] = rule.Level
+	}
 
 	if rule.Ignore != nil && len(rule.Ignore.Files) != 0 {
 		result[keyIgnore] = rule.Ignore
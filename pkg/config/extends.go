@@ -0,0 +1,258 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"dario.cat/mergo"
+	"gopkg.in/yaml.v3"
+)
+
+// Sources maps each setting of a configuration to the file it was set in. Settings are identified
+// by their path in the configuration, like "rules.style.line-length.level" for rule attributes,
+// "overrides.0" for overrides, or simply "ignore" for other top-level settings.
+type Sources map[string]string
+
+// FromFileWithSources decodes the configuration in file, merged on top of the configuration of any
+// files it extends, and returns it along with the source of each of its settings.
+func FromFileWithSources(file *os.File) (Config, Sources, error) {
+	conf, err := fromFile(file, nil)
+
+	return conf, conf.Sources, err
+}
+
+// SourcesOf returns the sources of each setting of conf as if all were set in source, like for
+// a configuration not loaded from a file, e.g. the default configuration.
+func SourcesOf(conf Config, source string) (Sources, error) {
+	var node yaml.Node
+	if err := node.Encode(conf); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	sources := make(Sources)
+
+	walkSettings(&node, func(setting string, _, _ *yaml.Node) {
+		sources[setting] = source
+	})
+
+	return sources, nil
+}
+
+func fromFile(file *os.File, chain []string) (Config, error) {
+	path, err := filepath.Abs(file.Name())
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to get absolute path for %s: %w", file.Name(), err)
+	}
+
+	if slices.Contains(chain, path) {
+		return Config{}, fmt.Errorf("circular extends: %s", strings.Join(append(chain, path), " -> "))
+	}
+
+	chain = append(slices.Clip(chain), path)
+
+	var node yaml.Node
+	if err := yaml.NewDecoder(file).Decode(&node); err != nil {
+		return Config{}, err
+	}
+
+	var conf Config
+	if err := node.Decode(&conf); err != nil {
+		return Config{}, err
+	}
+
+	conf.Sources = make(Sources)
+
+	walkSettings(&node, func(setting string, _, _ *yaml.Node) {
+		conf.Sources[setting] = file.Name()
+	})
+
+	if len(conf.Extends) == 0 {
+		return conf, nil
+	}
+
+	var base Config
+
+	for i, extends := range conf.Extends {
+		extended, err := fromExtends(extends, filepath.Dir(path), chain)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to load %s extended by %s: %w", extends, file.Name(), err)
+		}
+
+		if i == 0 {
+			base = extended
+
+			continue
+		}
+
+		if base, err = merge(base, extended); err != nil {
+			return Config{}, err
+		}
+	}
+
+	return merge(base, conf)
+}
+
+// fromExtends loads the configuration referenced by an entry in extends, which is either the name
+// of a preset, or the path of a file. Paths are relative to the directory of the configuration file
+// extending them.
+func fromExtends(extends, dir string, chain []string) (Config, error) {
+	if isPreset(extends) {
+		return Preset(extends)
+	}
+
+	path := extends
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	conf, err := fromFile(file, chain)
+	if errors.Is(err, io.EOF) {
+		// an empty file is valid, if not very useful, to extend
+		return Config{Sources: Sources{}}, nil
+	}
+
+	return conf, err
+}
+
+// isPreset returns true if the entry in extends names a preset rather than a file, i.e. it's not
+// a path with a directory or a file extension.
+func isPreset(extends string) bool {
+	return !strings.ContainsAny(extends, `/\`) && filepath.Ext(extends) == ""
+}
+
+// merge merges conf on top of base, using the same logic as when merging a user configuration with
+// the provided configuration. However, rules are merged attribute by attribute, like for overrides,
+// so that conf may e.g. change only the level of a rule configured in base. Overrides of conf are
+// appended to those of base, and capabilities of base are kept unless conf provides its own. The
+// sources and presets of both configurations are combined in the returned configuration.
+func merge(base, conf Config) (Config, error) {
+	rules := mergeRules(base.Rules, conf.Rules)
+	overrides := append(slices.Clip(base.Overrides), conf.Overrides...)
+	presets := append(slices.Clip(base.Presets), conf.Presets...)
+	offset := len(base.Overrides)
+
+	if _, ok := conf.Sources["capabilities"]; !ok && base.Capabilities != nil {
+		conf.Capabilities = nil
+		conf.CapabilitiesURL = ""
+	}
+
+	sources := maps.Clone(base.Sources)
+	if sources == nil {
+		sources = make(Sources, len(conf.Sources))
+	}
+
+	for setting, source := range conf.Sources {
+		// overrides are appended, so the index of those in conf is offset by those in base
+		if index, ok := strings.CutPrefix(setting, "overrides."); ok {
+			if i, err := strconv.Atoi(index); err == nil {
+				setting = "overrides." + strconv.Itoa(offset+i)
+			}
+		}
+
+		sources[setting] = source
+	}
+
+	conf.Sources = nil
+
+	if err := mergo.Merge(&base, conf, mergo.WithOverride); err != nil {
+		return Config{}, fmt.Errorf("failed to merge config: %w", err)
+	}
+
+	base.Rules = rules
+	base.Overrides = overrides
+	base.Presets = presets
+	base.Sources = sources
+	base.Extends = nil
+
+	return base, nil
+}
+
+// walkSettings calls fn for each setting in the configuration in node, as identified in Sources,
+// along with the nodes of its key and value.
+func walkSettings(node *yaml.Node, fn func(setting string, key, value *yaml.Node)) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		switch key.Value {
+		case "rules":
+			// rules.<category>.<rule>.<attribute>, or rules.default.level and rules.<category>.default.level
+			walkNested(key.Value, key, value, 3, fn)
+		case "overrides":
+			walkNested(key.Value, key, value, 1, fn)
+		default:
+			fn(key.Value, key, value)
+		}
+	}
+}
+
+func walkNested(setting string, key, value *yaml.Node, depth int, fn func(setting string, key, value *yaml.Node)) {
+	switch {
+	case depth > 0 && value.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			walkNested(setting+"."+value.Content[i].Value, value.Content[i], value.Content[i+1], depth-1, fn)
+		}
+	case depth > 0 && value.Kind == yaml.SequenceNode:
+		for i, item := range value.Content {
+			walkNested(setting+"."+strconv.Itoa(i), item, item, depth-1, fn)
+		}
+	default:
+		fn(setting, key, value)
+	}
+}
+
+// ToYAMLWithSources encodes the configuration as YAML, with each setting found in sources annotated
+// with a comment stating the file it was set in.
+func ToYAMLWithSources(conf Config, sources Sources) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(conf); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	walkSettings(&node, func(setting string, key, value *yaml.Node) {
+		source, ok := sources[setting]
+
+		switch {
+		case !ok:
+			return
+		case key == value:
+			value.HeadComment = source
+		case value.Kind == yaml.ScalarNode:
+			value.LineComment = source
+		default:
+			key.LineComment = source
+		}
+	})
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestFromFileWithExtends(t *testing.T) {
+	t.Parallel()
+
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"shared/base.yaml": `rules:
+  style:
+    line-length:
+      level: warning
+      max-line-length: 100
+    todo-comment:
+      level: ignore
+ignore:
+  files:
+    - vendor/
+overrides:
+  - files: [tests/**]
+    rules:
+      style:
+        line-length:
+          max-line-length: 150
+`,
+		"shared/strict.yaml": `rules:
+  style:
+    todo-comment:
+      level: error
+`,
+		"project/.regal/config.yaml": `extends:
+  - ../../shared/base.yaml
+  - ../../shared/strict.yaml
+rules:
+  style:
+    line-length:
+      max-line-length: 120
+overrides:
+  - files: [gen/**]
+    rules:
+      style:
+        prefer-snake-case:
+          level: ignore
+`,
+	})
+
+	base := filepath.Join(root, "shared", "base.yaml")
+	strict := filepath.Join(root, "shared", "strict.yaml")
+	user := filepath.Join(root, "project", ".regal", "config.yaml")
+
+	file := testutil.Must(os.Open(user))(t)
+	defer file.Close()
+
+	conf, sources, err := FromFileWithSources(file)
+	testutil.NoErr(err)(t)
+
+	expRules := map[string]Category{"style": {
+		"line-length":  {Level: "warning", Extra: ExtraAttributes{"max-line-length": 120}},
+		"todo-comment": {Level: "error", Extra: ExtraAttributes{}},
+	}}
+
+	if diff := cmp.Diff(expRules, conf.Rules); diff != "" {
+		t.Errorf("unexpected rules (-want, +got):\n%s", diff)
+	}
+
+	if len(conf.Overrides) != 2 || conf.Overrides[0].Files[0] != "tests/**" || conf.Overrides[1].Files[0] != "gen/**" {
+		t.Errorf("expected overrides of extended config to be followed by those of extending config, got %v", conf.Overrides)
+	}
+
+	if diff := cmp.Diff([]string{"vendor/"}, conf.Ignore.Files); diff != "" {
+		t.Errorf("unexpected ignore files (-want, +got):\n%s", diff)
+	}
+
+	if conf.Extends != nil {
+		t.Errorf("expected extends to be resolved, got %v", conf.Extends)
+	}
+
+	expSources := Sources{
+		"extends":                       user,
+		"ignore":                        base,
+		"overrides.0":                   base,
+		"overrides.1":                   user,
+		"rules.style.line-length.level": base,
+		"rules.style.line-length.max-line-length": user,
+		"rules.style.todo-comment.level":          strict,
+	}
+
+	if diff := cmp.Diff(expSources, sources); diff != "" {
+		t.Errorf("unexpected sources (-want, +got):\n%s", diff)
+	}
+
+	conf.Capabilities = nil
+
+	bs := testutil.Must(ToYAMLWithSources(conf, sources))(t)
+
+	for _, expected := range []string{"level: warning # " + base, "max-line-length: 120 # " + user, "ignore: # " + base} {
+		if !strings.Contains(string(bs), expected) {
+			t.Errorf("expected YAML to contain %q, got:\n%s", expected, bs)
+		}
+	}
+}
+
+func TestFromFileWithExtendsErrors(t *testing.T) {
+	t.Parallel()
+
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"circular.yaml": "extends: [other.yaml]\n",
+		"other.yaml":    "extends: [circular.yaml]\n",
+		"missing.yaml":  "extends: [not-found.yaml]\n",
+		"preset.yaml":   "extends: [unknown]\n",
+	})
+
+	testCases := map[string]string{
+		"circular.yaml": "circular extends",
+		"missing.yaml":  "failed to open config file",
+		"preset.yaml":   `unknown preset "unknown"`,
+	}
+
+	for name, expected := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			file := testutil.Must(os.Open(filepath.Join(root, name)))(t)
+			defer file.Close()
+
+			_, err := FromFile(file)
+
+			testutil.ErrMustContain(err, expected)(t)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+)
+
+// Override holds rule configuration that applies only to files matching any of the
+// patterns in Files. Patterns follow the same .gitignore like semantics as ignore patterns.
+// Rules configured in an override are merged on top of the configuration of the same rule,
+// so an override may e.g. set only the level of a rule, or only one of its attributes.
+type Override struct {
+	Files []string            `json:"files"           yaml:"files"`
+	Rules map[string]Category `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// ForPath returns the configuration in effect for the file at path, i.e. the configuration
+// with the rules of any overrides matching the path merged on top, in the order declared.
+// Like for ignore patterns, pathPrefix is trimmed from the path before matching, if provided.
+func (config Config) ForPath(path, pathPrefix string) (Config, error) {
+	overrides, err := config.MatchingOverrides(path, pathPrefix)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return config.WithOverridesApplied(overrides...), nil
+}
+
+// MatchingOverrides returns the overrides with any pattern matching the file at path,
+// in the order declared.
+func (config Config) MatchingOverrides(path, pathPrefix string) ([]Override, error) {
+	var matching []Override
+
+	for i, override := range config.Overrides {
+		patterns, err := compilePatterns(override.Files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile patterns of override %d: %w", i, err)
+		}
+
+		for _, pattern := range patterns {
+			if excludeFile(pattern, path, pathPrefix) {
+				matching = append(matching, override)
+
+				break
+			}
+		}
+	}
+
+	return matching, nil
+}
+
+// WithOverridesApplied returns a copy of the configuration with the rules of the provided
+// overrides merged on top of its rules, in order. As the overrides then have been applied,
+// the returned configuration has no overrides of its own. The receiver is not modified.
+func (config Config) WithOverridesApplied(overrides ...Override) Config {
+	config.Overrides = nil
+
+	if len(overrides) == 0 {
+		return config
+	}
+
+	rules := config.Rules
+
+	for _, override := range overrides {
+		rules = mergeRules(rules, override.Rules)
+	}
+
+	config.Rules = rules
+
+	return config
+}
+
+// mergeRules returns a new map of rules, with each rule in other merged on top of the same
+// rule in rules. Neither of the provided maps are modified.
+func mergeRules(rules, other map[string]Category) map[string]Category {
+	merged := maps.Clone(rules)
+	if merged == nil {
+		merged = make(map[string]Category, len(other))
+	}
+
+	for categoryName, category := range other {
+		mergedCategory := maps.Clone(merged[categoryName])
+		if mergedCategory == nil {
+			mergedCategory = make(Category, len(category))
+		}
+
+		for ruleName, rule := range category {
+			mergedCategory[ruleName] = mergeRule(mergedCategory[ruleName], rule)
+		}
+
+		merged[categoryName] = mergedCategory
+	}
+
+	return merged
+}
+
+// mergeRule returns base with the level, ignore patterns and extra attributes set in
+// override merged on top. Anything not set in override is kept as in base.
+func mergeRule(base, override Rule) Rule {
+	if override.Level != "" {
+		base.Level = override.Level
+	}
+
+	if override.Ignore != nil {
+		base.Ignore = override.Ignore
+	}
+
+	if len(override.Extra) > 0 {
+		extra := make(ExtraAttributes, len(base.Extra)+len(override.Extra))
+
+		maps.Copy(extra, base.Extra)
+		maps.Copy(extra, override.Extra)
+
+		base.Extra = extra
+	}
+
+	return base
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestForPath(t *testing.T) {
+	t.Parallel()
+
+	conf := testutil.MustUnmarshalYAML[Config](t, []byte(`rules:
+  style:
+    line-length:
+      level: error
+      max-line-length: 80
+      non-breakable-word-threshold: 60
+    prefer-snake-case:
+      level: error
+overrides:
+  - files:
+      - tests/**
+    rules:
+      style:
+        line-length:
+          max-line-length: 120
+  - files:
+      - vendor/**
+      - "*_generated.rego"
+    rules:
+      style:
+        line-length:
+          level: warning
+        prefer-snake-case:
+          level: ignore
+`))
+
+	testCases := map[string]struct {
+		path     string
+		expected map[string]Category
+	}{
+		"no override matching": {
+			path: "/root/policy/main.rego",
+			expected: map[string]Category{"style": {
+				"line-length": {
+					Level: "error",
+					Extra: ExtraAttributes{"max-line-length": 80, "non-breakable-word-threshold": 60},
+				},
+				"prefer-snake-case": {Level: "error", Extra: ExtraAttributes{}},
+			}},
+		},
+		"attribute overridden": {
+			path: "/root/tests/main_test.rego",
+			expected: map[string]Category{"style": {
+				"line-length": {
+					Level: "error",
+					Extra: ExtraAttributes{"max-line-length": 120, "non-breakable-word-threshold": 60},
+				},
+				"prefer-snake-case": {Level: "error", Extra: ExtraAttributes{}},
+			}},
+		},
+		"levels overridden": {
+			path: "/root/vendor/lib/lib.rego",
+			expected: map[string]Category{"style": {
+				"line-length": {
+					Level: "warning",
+					Extra: ExtraAttributes{"max-line-length": 80, "non-breakable-word-threshold": 60},
+				},
+				"prefer-snake-case": {Level: "ignore", Extra: ExtraAttributes{}},
+			}},
+		},
+		"multiple overrides applied in order": {
+			path: "/root/tests/policy_generated.rego",
+			expected: map[string]Category{"style": {
+				"line-length": {
+					Level: "warning",
+					Extra: ExtraAttributes{"max-line-length": 120, "non-breakable-word-threshold": 60},
+				},
+				"prefer-snake-case": {Level: "ignore", Extra: ExtraAttributes{}},
+			}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pathConf := testutil.Must(conf.ForPath(tc.path, "/root"))(t)
+
+			if diff := cmp.Diff(tc.expected, pathConf.Rules); diff != "" {
+				t.Errorf("unexpected rules (-want, +got):\n%s", diff)
+			}
+
+			if pathConf.Overrides != nil {
+				t.Errorf("expected no overrides in config for path, got %v", pathConf.Overrides)
+			}
+		})
+	}
+
+	// the config itself must be left as is
+	if conf.Rules["style"]["line-length"].Extra["max-line-length"] != 80 {
+		t.Errorf("expected max-line-length of config to be unchanged")
+	}
+}
+
+func TestUnmarshalConfigOverrideWithoutFiles(t *testing.T) {
+	t.Parallel()
+
+	var conf Config
+
+	err := yaml.Unmarshal([]byte("overrides:\n  - rules: {}\n"), &conf)
+
+	testutil.ErrMustContain(err, "override 0 must provide at least one pattern in files")(t)
+}
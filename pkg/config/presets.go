@@ -0,0 +1,147 @@
+package config
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// presetSourcePrefix is used in Sources to denote settings provided by a preset, as opposed to a file.
+const presetSourcePrefix = "preset:"
+
+//go:embed presets/*.yaml
+var presetsFS embed.FS
+
+// Presets returns the names of the built-in presets, in alphabetical order.
+func Presets() []string {
+	entries, err := presetsFS.ReadDir("presets")
+	if err != nil {
+		panic(err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+
+	return names
+}
+
+// Preset returns the configuration of the built-in preset with the provided name.
+func Preset(name string) (Config, error) {
+	bs, err := presetsFS.ReadFile("presets/" + name + ".yaml")
+	if err != nil {
+		return Config{}, fmt.Errorf("unknown preset %q, available presets are: %s",
+			name, strings.Join(Presets(), ", "))
+	}
+
+	var node yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(bs)).Decode(&node); err != nil {
+		return Config{}, fmt.Errorf("failed to decode preset %s: %w", name, err)
+	}
+
+	var conf Config
+	if err := node.Decode(&conf); err != nil {
+		return Config{}, fmt.Errorf("failed to decode preset %s: %w", name, err)
+	}
+
+	conf.Sources = make(Sources)
+	conf.Presets = []string{name}
+
+	walkSettings(&node, func(setting string, _, _ *yaml.Node) {
+		conf.Sources[setting] = presetSourcePrefix + name
+	})
+
+	return conf, nil
+}
+
+// WithPreset returns the configuration with the named preset merged beneath it, as if the preset
+// had been listed first in extends. Settings of the configuration thus take precedence over those
+// of the preset.
+func WithPreset(conf Config, name string) (Config, error) {
+	preset, err := Preset(name)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if conf.Sources == nil {
+		conf.Sources = unknownSources(conf)
+	}
+
+	return merge(preset, conf)
+}
+
+// unknownSources returns sources for the settings relevant to merging a configuration not loaded
+// from a file, i.e. rule levels and capabilities, with the source of each left empty as unknown.
+func unknownSources(conf Config) Sources {
+	sources := make(Sources)
+
+	if conf.Capabilities != nil {
+		sources["capabilities"] = ""
+	}
+
+	if conf.Defaults.Global.Level != "" {
+		sources["rules.default.level"] = ""
+	}
+
+	for category, categoryDefault := range conf.Defaults.Categories {
+		if categoryDefault.Level != "" {
+			sources["rules."+category+".default.level"] = ""
+		}
+	}
+
+	for category, rules := range conf.Rules {
+		for title, rule := range rules {
+			if rule.Level != "" {
+				sources["rules."+category+"."+title+".level"] = ""
+			}
+		}
+	}
+
+	return sources
+}
+
+// PresetFromSource returns the name of the preset if the source of a setting is a preset,
+// and false if the setting was provided by a file.
+func PresetFromSource(source string) (string, bool) {
+	if name, ok := strings.CutPrefix(source, presetSourcePrefix); ok {
+		return name, true
+	}
+
+	return "", false
+}
+
+// EnabledByPreset returns the name of the preset that enabled the rule, if any. This is the preset
+// providing the level in effect for the rule, be it the level of the rule itself, or the default
+// level of its category or of all rules. A rule whose level is set by none of those is considered
+// enabled by the last preset applied, as the preset then kept the rule enabled by default.
+func (config Config) EnabledByPreset(category, title string) (string, bool) {
+	settings := []struct {
+		name  string
+		level string
+	}{
+		{"rules." + category + "." + title + ".level", config.Rules[category][title].Level},
+		{"rules." + category + ".default.level", config.Defaults.Categories[category].Level},
+		{"rules.default.level", config.Defaults.Global.Level},
+	}
+
+	for _, setting := range settings {
+		if source, ok := config.Sources[setting.name]; ok {
+			if setting.level == "ignore" {
+				return "", false
+			}
+
+			return PresetFromSource(source)
+		}
+	}
+
+	if len(config.Presets) == 0 || config.Rules[category][title].Level == "ignore" {
+		return "", false
+	}
+
+	return config.Presets[len(config.Presets)-1], true
+}
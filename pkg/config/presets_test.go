@@ -0,0 +1,103 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestPresets(t *testing.T) {
+	t.Parallel()
+
+	if exp, got := []string{"minimal", "recommended", "rego-v1-migration", "strict"}, Presets(); !slices.Equal(exp, got) {
+		t.Fatalf("expected presets %v, got %v", exp, got)
+	}
+
+	for _, name := range Presets() {
+		conf := testutil.Must(Preset(name))(t)
+
+		if !slices.Equal(conf.Presets, []string{name}) {
+			t.Errorf("expected preset %s to be recorded in config, got %v", name, conf.Presets)
+		}
+	}
+
+	_, err := Preset("unknown")
+
+	testutil.ErrMustContain(err, `unknown preset "unknown", available presets are: minimal, recommended`)(t)
+}
+
+func TestFromFileExtendingPreset(t *testing.T) {
+	t.Parallel()
+
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"base.yaml": `rules:
+  style:
+    line-length:
+      level: error
+`,
+		".regal/config.yaml": `extends:
+  - minimal
+  - ../base.yaml
+rules:
+  bugs:
+    constant-condition:
+      level: ignore
+`,
+	})
+
+	file := testutil.Must(os.Open(filepath.Join(root, ".regal", "config.yaml")))(t)
+	defer file.Close()
+
+	conf := testutil.Must(FromFile(file))(t)
+
+	if !slices.Equal(conf.Presets, []string{"minimal"}) {
+		t.Errorf("expected presets [minimal], got %v", conf.Presets)
+	}
+
+	if conf.Defaults.Global.Level != "ignore" || conf.Defaults.Categories["bugs"].Level != "error" {
+		t.Errorf("expected defaults of minimal preset, got %v", conf.Defaults)
+	}
+
+	testCases := []struct {
+		category string
+		title    string
+		preset   string
+	}{
+		{"bugs", "duplicate-rule", "minimal"},
+		{"bugs", "constant-condition", ""},
+		{"style", "line-length", ""},
+		{"style", "prefer-snake-case", ""},
+	}
+
+	for _, tc := range testCases {
+		preset, _ := conf.EnabledByPreset(tc.category, tc.title)
+		if preset != tc.preset {
+			t.Errorf("expected %s to be enabled by preset %q, got %q", tc.title, tc.preset, preset)
+		}
+	}
+}
+
+func TestWithPreset(t *testing.T) {
+	t.Parallel()
+
+	userConfig := Config{
+		Rules: map[string]Category{"style": {"line-length": {Level: "warning"}, "opa-fmt": {}}},
+	}
+
+	conf := testutil.Must(WithPreset(userConfig, "strict"))(t)
+
+	if conf.Defaults.Global.Level != "error" || conf.Defaults.Categories["custom"].Level != "ignore" {
+		t.Errorf("expected defaults of strict preset, got %v", conf.Defaults)
+	}
+
+	if preset, ok := conf.EnabledByPreset("style", "opa-fmt"); !ok || preset != "strict" {
+		t.Errorf("expected opa-fmt to be enabled by strict preset, got %q", preset)
+	}
+
+	if preset, ok := conf.EnabledByPreset("style", "line-length"); ok {
+		t.Errorf("expected line-length to be enabled by user config, got preset %q", preset)
+	}
+}
@@ -0,0 +1,285 @@
+package config
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema holds the known rules of each category, and the attributes known for each rule, used to
+// validate configuration against. A rule with a nil list of attributes accepts any attribute, which
+// is the case for custom rules, as their attributes can't be known in advance.
+type Schema map[string]map[string][]string
+
+// Problem is an issue found when validating configuration, located at the line and column of the
+// offending node in the YAML document.
+type Problem struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+}
+
+var (
+	settings = []string{
+		"rules", "ignore", "capabilities", "features", "project", "overrides", "extends", "thresholds",
+	}
+	levels           = []string{"error", "warning", "info", "hint", "ignore"}
+	ruleAttributes   = []string{keyLevel, keyIgnore}
+	overrideSettings = []string{"files", "rules"}
+)
+
+// Validate validates the configuration in node against the schema, and returns any problems found,
+// like unknown categories, rules, or rule attributes, in the order they appear in the document.
+// Note that only the document itself is validated, and not the configuration of any files it extends.
+func Validate(node *yaml.Node, schema Schema) []Problem {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return []Problem{problemAt(node, "configuration must be an object")}
+	}
+
+	var problems []Problem
+
+	for key, value := range mapping(node) {
+		switch key.Value {
+		case "rules":
+			problems = append(problems, validateRules(value, schema)...)
+		case "overrides":
+			problems = append(problems, validateOverrides(value, schema)...)
+		case "thresholds":
+			problems = append(problems, validateThresholds(value, schema)...)
+		default:
+			if !slices.Contains(settings, key.Value) {
+				problems = append(problems, problemAt(key, "unknown setting %q", key.Value))
+			}
+		}
+	}
+
+	return problems
+}
+
+func validateOverrides(node *yaml.Node, schema Schema) []Problem {
+	if node.Kind != yaml.SequenceNode {
+		return []Problem{problemAt(node, "overrides must be a list")}
+	}
+
+	var problems []Problem
+
+	for _, override := range node.Content {
+		if override.Kind != yaml.MappingNode {
+			problems = append(problems, problemAt(override, "override must be an object"))
+
+			continue
+		}
+
+		for key, value := range mapping(override) {
+			switch {
+			case key.Value == "rules":
+				problems = append(problems, validateRules(value, schema)...)
+			case !slices.Contains(overrideSettings, key.Value):
+				problems = append(problems, problemAt(key, "unknown override setting %q", key.Value))
+			}
+		}
+	}
+
+	return problems
+}
+
+func validateThresholds(node *yaml.Node, schema Schema) []Problem {
+	if node.Kind != yaml.MappingNode {
+		return []Problem{problemAt(node, "thresholds must be an object")}
+	}
+
+	var problems []Problem
+
+	for key, value := range mapping(node) {
+		switch key.Value {
+		case keyMaxWarnings:
+			problems = append(problems, validateMaxWarnings(value)...)
+		case "categories":
+			problems = append(problems, validateCategoryThresholds(value, schema)...)
+		default:
+			problems = append(problems, problemAt(key, "unknown thresholds setting %q", key.Value))
+		}
+	}
+
+	return problems
+}
+
+func validateCategoryThresholds(node *yaml.Node, schema Schema) []Problem {
+	if node.Kind != yaml.MappingNode {
+		return []Problem{problemAt(node, "threshold categories must be an object")}
+	}
+
+	var problems []Problem
+
+	for category, threshold := range mapping(node) {
+		if _, ok := schema[category.Value]; !ok {
+			problems = append(problems, problemAt(category, "unknown category %q", category.Value))
+
+			continue
+		}
+
+		if threshold.Kind != yaml.MappingNode {
+			problems = append(problems, problemAt(threshold, "threshold of category %q must be an object", category.Value))
+
+			continue
+		}
+
+		for key, value := range mapping(threshold) {
+			if key.Value != keyMaxWarnings {
+				problems = append(problems, problemAt(key,
+					"unknown attribute %q for threshold of category %q, only max-warnings is supported",
+					key.Value, category.Value,
+				))
+
+				continue
+			}
+
+			problems = append(problems, validateMaxWarnings(value)...)
+		}
+	}
+
+	return problems
+}
+
+func validateMaxWarnings(node *yaml.Node) []Problem {
+	if n, err := strconv.Atoi(node.Value); node.Kind == yaml.ScalarNode && err == nil && n >= 0 {
+		return nil
+	}
+
+	return []Problem{problemAt(node, "invalid max-warnings %q, must be a non-negative integer", node.Value)}
+}
+
+func validateRules(node *yaml.Node, schema Schema) []Problem {
+	if node.Kind != yaml.MappingNode {
+		return []Problem{problemAt(node, "rules must be an object")}
+	}
+
+	var problems []Problem
+
+	for category, rules := range mapping(node) {
+		if category.Value == "default" {
+			problems = append(problems, validateDefault(rules)...)
+
+			continue
+		}
+
+		known, ok := schema[category.Value]
+		if !ok {
+			problems = append(problems, problemAt(category, "unknown category %q", category.Value))
+
+			continue
+		}
+
+		if rules.Kind != yaml.MappingNode {
+			problems = append(problems, problemAt(rules, "rules of category %q must be an object", category.Value))
+
+			continue
+		}
+
+		for title, rule := range mapping(rules) {
+			if title.Value == "default" {
+				problems = append(problems, validateDefault(rule)...)
+
+				continue
+			}
+
+			attributes, ok := known[title.Value]
+			if !ok {
+				problems = append(problems, unknownRule(title, category.Value, schema))
+
+				continue
+			}
+
+			problems = append(problems, validateRule(title.Value, rule, attributes)...)
+		}
+	}
+
+	return problems
+}
+
+func validateDefault(node *yaml.Node) []Problem {
+	if node.Kind != yaml.MappingNode {
+		return []Problem{problemAt(node, "default must be an object")}
+	}
+
+	var problems []Problem
+
+	for key, value := range mapping(node) {
+		if key.Value != keyLevel {
+			problems = append(problems, problemAt(key, "unknown attribute %q for default, only level is supported", key.Value))
+
+			continue
+		}
+
+		problems = append(problems, validateLevel(value)...)
+	}
+
+	return problems
+}
+
+func validateRule(title string, node *yaml.Node, attributes []string) []Problem {
+	if node.Kind != yaml.MappingNode {
+		return []Problem{problemAt(node, "configuration of rule %q must be an object", title)}
+	}
+
+	var problems []Problem
+
+	for key, value := range mapping(node) {
+		switch {
+		case key.Value == keyLevel:
+			problems = append(problems, validateLevel(value)...)
+		case slices.Contains(ruleAttributes, key.Value):
+		case attributes != nil && !slices.Contains(attributes, key.Value):
+			problems = append(problems, problemAt(key, "unknown attribute %q for rule %q", key.Value, title))
+		}
+	}
+
+	return problems
+}
+
+func validateLevel(node *yaml.Node) []Problem {
+	if node.Kind == yaml.ScalarNode && slices.Contains(levels, node.Value) {
+		return nil
+	}
+
+	return []Problem{problemAt(node, "invalid level %q, must be one of %s", node.Value, strings.Join(levels, ", "))}
+}
+
+// unknownRule reports a rule unknown in category, but points out the category of the rule if it's
+// known in another.
+func unknownRule(title *yaml.Node, category string, schema Schema) Problem {
+	for other, rules := range schema {
+		if _, ok := rules[title.Value]; ok {
+			return problemAt(title, "unknown rule %q in category %q, rule is in category %q", title.Value, category, other)
+		}
+	}
+
+	return problemAt(title, "unknown rule %q in category %q", title.Value, category)
+}
+
+// mapping iterates over the key and value nodes of a mapping node.
+func mapping(node *yaml.Node) iter.Seq2[*yaml.Node, *yaml.Node] {
+	return func(yield func(key, value *yaml.Node) bool) {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if !yield(node.Content[i], node.Content[i+1]) {
+				return
+			}
+		}
+	}
+}
+
+func problemAt(node *yaml.Node, format string, args ...any) Problem {
+	return Problem{Message: fmt.Sprintf(format, args...), Line: node.Line, Column: node.Column}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	schema := Schema{
+		"style": {
+			"line-length": {"max-line-length"},
+			"opa-fmt":     {},
+		},
+		"custom": {
+			"my-rule": nil,
+		},
+	}
+
+	testCases := map[string]struct {
+		config   string
+		expected []Problem
+	}{
+		"valid": {
+			config: `rules:
+  default:
+    level: warning
+  style:
+    default:
+      level: error
+    line-length:
+      level: ignore
+      max-line-length: 100
+      ignore:
+        files: [a.rego]
+  custom:
+    my-rule:
+      anything: goes
+overrides:
+  - files: [tests/**]
+    rules:
+      style:
+        opa-fmt:
+          level: warning
+ignore:
+  files: [vendor/]
+extends: [minimal]
+thresholds:
+  max-warnings: 10
+  categories:
+    style:
+      max-warnings: 5
+`,
+		},
+		"unknown category": {
+			config: "rules:\n  stlye: {}\n",
+			expected: []Problem{
+				{Message: `unknown category "stlye"`, Line: 2, Column: 3},
+			},
+		},
+		"unknown rule": {
+			config: "rules:\n  style:\n    line-lenght: {}\n  custom:\n    opa-fmt: {}\n",
+			expected: []Problem{
+				{Message: `unknown rule "line-lenght" in category "style"`, Line: 3, Column: 5},
+				{Message: `unknown rule "opa-fmt" in category "custom", rule is in category "style"`, Line: 5, Column: 5},
+			},
+		},
+		"unknown attribute": {
+			config: "rules:\n  style:\n    line-length:\n      max-line-lenght: 100\n",
+			expected: []Problem{
+				{Message: `unknown attribute "max-line-lenght" for rule "line-length"`, Line: 4, Column: 7},
+			},
+		},
+		"invalid level": {
+			config: "rules:\n  default:\n    level: errror\n    max: 1\n",
+			expected: []Problem{
+				{Message: `invalid level "errror", must be one of error, warning, info, hint, ignore`, Line: 3, Column: 12},
+				{Message: `unknown attribute "max" for default, only level is supported`, Line: 4, Column: 5},
+			},
+		},
+		"invalid thresholds": {
+			config: "thresholds:\n  max-warnings: -1\n  categories:\n    stlye: {}\n    style:\n      max: 1\n",
+			expected: []Problem{
+				{Message: `invalid max-warnings "-1", must be a non-negative integer`, Line: 2, Column: 17},
+				{Message: `unknown category "stlye"`, Line: 4, Column: 5},
+				{
+					Message: `unknown attribute "max" for threshold of category "style", only max-warnings is supported`,
+					Line:    6,
+					Column:  7,
+				},
+			},
+		},
+		"unknown settings": {
+			config: "ignores: {}\noverrides:\n  - files: [a]\n    rule: {}\n",
+			expected: []Problem{
+				{Message: `unknown setting "ignores"`, Line: 1, Column: 1},
+				{Message: `unknown override setting "rule"`, Line: 4, Column: 5},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var node yaml.Node
+
+			testutil.NoErr(yaml.Unmarshal([]byte(tc.config), &node))(t)
+
+			if diff := cmp.Diff(tc.expected, Validate(&node, schema)); diff != "" {
+				t.Errorf("unexpected problems (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
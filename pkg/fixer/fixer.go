@@ -191,6 +191,15 @@ func (f *Fixer) applyLinterFixes(
 		versionsMap = f.versionsMap
 	}
 
+	conf, err := l.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+
+	// fixable rules are enabled for all files below, so any ignored for some files by overrides
+	// must be skipped for those files when fixing
+	hasOverrides := len(conf.Overrides) > 0
+
 	li, err := l.WithDisableAll(true).WithEnabledRules(fixableEnabledRules...).Prepare(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to prepare linter for fixing: %w", err)
@@ -219,12 +228,16 @@ func (f *Fixer) applyLinterFixes(
 				return fmt.Errorf("no fix for violation %s", rep.Violations[i].Title)
 			}
 
-			config, err := l.GetConfig()
+			file := rep.Violations[i].Location.File
+
+			config, err := l.GetConfigForPath(file)
 			if err != nil {
 				return fmt.Errorf("failed to get config: %w", err)
 			}
 
-			file := rep.Violations[i].Location.File
+			if hasOverrides && config.Rules[rep.Violations[i].Category][rep.Violations[i].Title].Level == "ignore" {
+				continue
+			}
 
 			abs, err := filepath.Abs(file)
 			if err != nil {
@@ -0,0 +1,179 @@
+package linter
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+
+	"github.com/open-policy-agent/regal/pkg/report"
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
+	"github.com/open-policy-agent/regal/pkg/version"
+)
+
+// resultCache is a content-addressed, on-disk cache of per-file lint results.
+// Entries are keyed on the file name and contents, as well as a salt derived from
+// everything else that may affect the outcome of linting a file: the effective
+// configuration and parameters, the rules evaluated (built-in and custom) and the
+// version of Regal. Entries that are no longer valid are thus never read, and
+// the cache directory may safely be deleted at any time.
+type resultCache struct {
+	dir  string
+	salt []byte
+	// now returns the current time, which is replaced in tests
+	now func() time.Time
+}
+
+// cachedResult is the subset of a per-file report.Report stored in the cache.
+type cachedResult struct {
+	Aggregates           map[string][]report.Aggregate   `json:"aggregates,omitempty"`
+	IgnoreDirectives     map[string]map[string][]string  `json:"ignore_directives,omitempty"`
+	IgnoreRanges         map[string][]report.IgnoreRange `json:"ignore_ranges,omitempty"`
+	UsedIgnoreDirectives map[string]map[string][]string  `json:"used_ignore_directives,omitempty"`
+	UsedIgnoreRanges     map[string]map[string][]string  `json:"used_ignore_ranges,omitempty"`
+	EvaluatedRules       map[string][]string             `json:"evaluated_rules,omitempty"`
+	Violations           []report.Violation              `json:"violations,omitempty"`
+	Notices              []report.Notice                 `json:"notices,omitempty"`
+}
+
+func (l Linter) newResultCache() (*resultCache, error) {
+	h := sha256.New()
+
+	h.Write([]byte(version.Version))
+
+	// encoding/json sorts map keys, which makes this deterministic across runs
+	data, err := json.Marshal(l.dataBundle.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal linter data: %w", err)
+	}
+
+	h.Write(data)
+
+	for _, b := range l.ruleBundles {
+		for _, m := range b.Modules {
+			h.Write([]byte(m.Path))
+			h.Write(m.Raw)
+		}
+	}
+
+	// custom rules are loaded from a map, so sort them for the salt to be the same between runs
+	customRuleModules := slices.SortedFunc(slices.Values(l.customRuleModules), func(a, b *ast.Module) int {
+		return cmp.Or(
+			strings.Compare(a.Package.Location.File, b.Package.Location.File),
+			a.Package.Path.Compare(b.Package.Path),
+		)
+	})
+
+	for _, m := range customRuleModules {
+		h.Write([]byte(m.String()))
+	}
+
+	return &resultCache{dir: l.cacheDir, salt: h.Sum(nil), now: time.Now}, nil
+}
+
+func (c *resultCache) key(name, content, regoVersion string, collect bool) string {
+	h := sha256.New()
+
+	h.Write(c.salt)
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write([]byte(regoVersion))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatBool(collect)))
+	h.Write([]byte{0})
+	h.Write([]byte(content))
+
+	// ignore directives may expire, so the result for a file with directives
+	// setting an expiry date is only valid on the day it was linted
+	if strings.Contains(content, "until=") {
+		h.Write([]byte{0})
+		h.Write([]byte(c.now().UTC().Format(time.DateOnly)))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *resultCache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// get returns the cached result for key, if present. Any error reading the entry
+// is treated as a cache miss, as the file will simply be linted again.
+func (c *resultCache) get(key string) (report.Report, bool) {
+	bs, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return report.Report{}, false
+	}
+
+	var cached cachedResult
+	if err := encoding.JSON().Unmarshal(bs, &cached); err != nil {
+		return report.Report{}, false
+	}
+
+	return report.Report{
+		Aggregates:           cached.Aggregates,
+		IgnoreDirectives:     cached.IgnoreDirectives,
+		IgnoreRanges:         cached.IgnoreRanges,
+		UsedIgnoreDirectives: cached.UsedIgnoreDirectives,
+		UsedIgnoreRanges:     cached.UsedIgnoreRanges,
+		EvaluatedRules:       cached.EvaluatedRules,
+		Violations:           cached.Violations,
+		Notices:              cached.Notices,
+	}, true
+}
+
+// put stores the result for key. The entry is written to a temporary file first
+// and then renamed, so that concurrent runs sharing a cache directory never read
+// partially written entries.
+func (c *resultCache) put(key string, r report.Report) error {
+	bs, err := encoding.JSON().Marshal(cachedResult{
+		Aggregates:           r.Aggregates,
+		IgnoreDirectives:     r.IgnoreDirectives,
+		IgnoreRanges:         r.IgnoreRanges,
+		UsedIgnoreDirectives: r.UsedIgnoreDirectives,
+		UsedIgnoreRanges:     r.UsedIgnoreRanges,
+		EvaluatedRules:       r.EvaluatedRules,
+		Violations:           r.Violations,
+		Notices:              r.Notices,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+
+	if _, err = tmp.Write(bs); err == nil {
+		err = tmp.Close()
+	} else {
+		_ = tmp.Close()
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
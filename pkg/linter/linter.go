@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"io/fs"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 	"testing/fstest"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 	"gopkg.in/yaml.v3"
@@ -48,6 +51,8 @@ type Linter struct {
 	combinedCfg          *config.Config
 	dataBundle           *bundle.Bundle
 	pathPrefix           string
+	preset               string
+	cacheDir             string
 	customRuleError      error
 	inputPaths           []string
 	ruleBundles          []*bundle.Bundle
@@ -55,12 +60,18 @@ type Linter struct {
 	disableCategory      []string
 	enable               []string
 	enableCategory       []string
+	disableTag           []string
+	enableTag            []string
 	ignoreFiles          []string
 	customRuleModules    []*ast.Module
 	overriddenAggregates map[string][]report.Aggregate
+	resultHandler        func(FileResult) error
+	concurrency          int
+	ruleTimeout          time.Duration
 	useCollectQuery      bool
 	debugMode            bool
 	exportAggregates     bool
+	reportUnusedIgnores  bool
 	disableAll           bool
 	enableAll            bool
 	profiling            bool
@@ -68,6 +79,20 @@ type Linter struct {
 	isPrepared           bool
 
 	preparedQuery *rego.PreparedEvalQuery
+	resultCache   *resultCache
+	ruleFileNames map[string]string
+}
+
+// FileResult is the result of linting a single file, or of evaluating the aggregate
+// rules, as provided to the function registered with WithFileResultHandler.
+type FileResult struct {
+	// File is the name of the file linted, or empty for the result of the aggregate rules.
+	File       string
+	Violations []report.Violation
+	Notices    []report.Notice
+	// Aggregate is true for the results only available once all files have been linted, i.e.
+	// the result of the aggregate rules, and of unused ignore directives, which are provided last.
+	Aggregate bool
 }
 
 var (
@@ -80,6 +105,7 @@ var (
 func init() {
 	ast.InternStringTerm(
 		"eval", "disable_all", "disable_category", "disable", "enable_all", "enable_category", "enable", "ignore_files",
+		"disable_tag", "enable_tag",
 	)
 }
 
@@ -167,6 +193,15 @@ func (l Linter) WithUserConfig(cfg config.Config) Linter {
 	return l.notPrepared()
 }
 
+// WithPreset applies the named built-in preset beneath the user config, as if the preset had been
+// listed first in extends. Rules configured by the user, or enabled or disabled by other options,
+// thus take precedence over the preset.
+func (l Linter) WithPreset(name string) Linter {
+	l.preset = name
+
+	return l.notPrepared()
+}
+
 // WithDisabledRules disables provided rules. This overrides configuration provided in file.
 func (l Linter) WithDisabledRules(disable ...string) Linter {
 	l.disable = disable
@@ -209,6 +244,20 @@ func (l Linter) WithEnabledCategories(enableCategory ...string) Linter {
 	return l.notPrepared()
 }
 
+// WithDisabledTags disables rules tagged with any of the provided tags. This overrides configuration provided in file.
+func (l Linter) WithDisabledTags(disableTag ...string) Linter {
+	l.disableTag = disableTag
+
+	return l.notPrepared()
+}
+
+// WithEnabledTags enables rules tagged with any of the provided tags. This overrides configuration provided in file.
+func (l Linter) WithEnabledTags(enableTag ...string) Linter {
+	l.enableTag = enableTag
+
+	return l.notPrepared()
+}
+
 // WithIgnore excludes files matching patterns. This overrides configuration provided in file.
 func (l Linter) WithIgnore(ignore []string) Linter {
 	l.ignoreFiles = ignore
@@ -261,6 +310,16 @@ func (l Linter) WithExportAggregates(enabled bool) Linter {
 	return l
 }
 
+// WithReportUnusedIgnores enables reporting of ignore directives, and block ignore directives,
+// that didn't suppress any violation, as violations of the unused-ignore-directive rule, for all
+// files linted. Without this option, they are reported only for the files where the rule is
+// enabled by configuration.
+func (l Linter) WithReportUnusedIgnores(enabled bool) Linter {
+	l.reportUnusedIgnores = enabled
+
+	return l.notPrepared()
+}
+
 // WithCollectQuery forcibly enables the collect query even when there is
 // only one file to lint.
 func (l Linter) WithCollectQuery(enabled bool) Linter {
@@ -287,6 +346,50 @@ func (l Linter) WithBaseCache(baseCache topdown.BaseCache) Linter {
 	return l
 }
 
+// WithFileResultHandler registers a function to be called with the result of each file as
+// soon as it has been linted, and finally with the result of the aggregate rules, if any were
+// evaluated. This allows reporting progress while linting is in progress, rather than having
+// to wait for the final report. The handler is called from multiple goroutines, but never
+// concurrently. An error returned from the handler aborts linting. Note that the final report
+// returned by Lint still contains all violations.
+func (l Linter) WithFileResultHandler(handler func(FileResult) error) Linter {
+	l.resultHandler = handler
+
+	return l
+}
+
+// WithCacheDir enables caching of per-file lint results in the provided directory.
+// Files that have not changed since a previous run, with the same configuration,
+// rules and version of Regal, are then not evaluated again. Aggregate rules are still
+// evaluated on every run, but the aggregate data of unchanged files is read from the
+// cache. Caching is disabled when profiling or print statements are enabled.
+func (l Linter) WithCacheDir(dir string) Linter {
+	l.cacheDir = dir
+
+	return l.notPrepared()
+}
+
+// WithConcurrency limits the number of files linted concurrently to n. When set, files
+// provided by path are also read and parsed only when about to be linted, rather than all
+// up front, which bounds memory usage when linting a large number of files. The default of
+// 0 means no limit, which is usually the fastest option given enough memory.
+func (l Linter) WithConcurrency(n int) Linter {
+	l.concurrency = n
+
+	return l
+}
+
+// WithRuleTimeout sets a time budget for evaluating the rules for each file. Should the
+// evaluation of a file exceed it, the rules are evaluated again for that file one at a time,
+// sharing a second budget of the same length, and any rule not evaluated within that budget
+// is skipped for the file, and reported as a notice naming the rule and the file, while
+// linting continues. The default of 0 means no budget.
+func (l Linter) WithRuleTimeout(timeout time.Duration) Linter {
+	l.ruleTimeout = timeout
+
+	return l.notPrepared()
+}
+
 // Prepare stores linter preparation state, like the determined configuration,
 // and the query perpared for linting.
 // Experimental: while used internally, the details of what is prepared here
@@ -308,10 +411,18 @@ func (l Linter) Prepare(ctx context.Context) (Linter, error) {
 	l.combinedCfg = conf
 	l.dataBundle = l.createDataBundle(*conf)
 
+	if l.cacheDir != "" {
+		if l.resultCache, err = l.newResultCache(); err != nil {
+			return l, fmt.Errorf("failed to create result cache: %w", err)
+		}
+	}
+
 	if l.preparedQuery, err = l.prepareQuery(ctx); err != nil {
 		return l, fmt.Errorf("failed to prepare query: %w", err)
 	}
 
+	l.ruleFileNames = l.ruleFiles()
+
 	l.isPrepared = true
 
 	return l, nil
@@ -364,15 +475,16 @@ func (l Linter) Lint(ctx context.Context) (report.Report, error) {
 		}
 	}
 
-	inputFromPaths, err := rules.InputFromPaths(filtered, l.pathPrefix, versionsMap)
-	if err != nil {
+	var input rules.Input
+
+	if l.concurrency > 0 && !(len(filtered) == 1 && filtered[0] == "-") {
+		input = rules.LazyInputFromPaths(filtered, l.pathPrefix, versionsMap)
+	} else if input, err = rules.InputFromPaths(filtered, l.pathPrefix, versionsMap); err != nil {
 		return report.Report{}, fmt.Errorf("errors encountered when reading files to lint: %w", err)
 	}
 
 	l.stopTimer(regalmetrics.RegalInputParse)
 
-	input := inputFromPaths
-
 	if l.inputModules != nil {
 		l.startTimer(regalmetrics.RegalFilterIgnoredModules)
 
@@ -403,11 +515,12 @@ func (l Linter) Lint(ctx context.Context) (report.Report, error) {
 		return strings.Compare(a.Title, b.Title)
 	})
 	regoReport.Notices = slices.Compact(regoReport.Notices)
-	rulesSkippedCounter := 0
+	// the same rule may be skipped for several files, like when exceeding the rule timeout
+	rulesSkipped := util.NewSet[string]()
 
 	for _, notice := range regoReport.Notices {
 		if notice.Severity != "none" {
-			rulesSkippedCounter++
+			rulesSkipped.Add(notice.Category + "/" + notice.Title)
 		}
 	}
 
@@ -424,22 +537,50 @@ func (l Linter) Lint(ctx context.Context) (report.Report, error) {
 	}
 
 	if len(allAggregates) > 0 {
-		aggregateReport, err := l.lintWithAggregateRules(ctx, allAggregates, regoReport.IgnoreDirectives)
+		aggregateReport, err := l.lintWithAggregateRules(
+			ctx, allAggregates, regoReport.IgnoreDirectives, regoReport.IgnoreRanges,
+		)
 		if err != nil {
 			return report.Report{}, fmt.Errorf("failed to lint using Rego aggregate rules: %w", err)
 		}
 
 		regoReport.Violations = append(regoReport.Violations, aggregateReport.Violations...)
+		mergeUsedIgnoreDirectives(regoReport.UsedIgnoreDirectives, aggregateReport.UsedIgnoreDirectives)
+		mergeUsedIgnoreDirectives(regoReport.UsedIgnoreRanges, aggregateReport.UsedIgnoreRanges)
+
+		if l.resultHandler != nil {
+			if err := l.resultHandler(FileResult{Violations: aggregateReport.Violations, Aggregate: true}); err != nil {
+				return report.Report{}, fmt.Errorf("result handler failed: %w", err)
+			}
+		}
 
 		if l.profiling {
-			regoReport.AggregateProfile = aggregateReport.AggregateProfile
+			regoReport.AddProfileEntries(aggregateReport.AggregateProfile)
+			regoReport.AddRuleProfileEntries(aggregateReport.AggregateRuleProfile)
 		}
 	}
 
+	if l.reportUnusedIgnores || evaluatedInAnyFile(regoReport, unusedIgnoreDirectiveKey) {
+		unused, err := l.unusedIgnoreDirectives(ctx, regoReport, allAggregates)
+		if err != nil {
+			return report.Report{}, err
+		}
+
+		regoReport.Violations = append(regoReport.Violations, unused...)
+
+		if l.resultHandler != nil && len(unused) > 0 {
+			if err := l.resultHandler(FileResult{Violations: unused, Aggregate: true}); err != nil {
+				return report.Report{}, fmt.Errorf("result handler failed: %w", err)
+			}
+		}
+	}
+
+	regoReport.UsedIgnoreDirectives, regoReport.UsedIgnoreRanges, regoReport.EvaluatedRules = nil, nil, nil
+
 	regoReport.Summary = report.Summary{
 		FilesScanned:  len(input.FileNames),
 		FilesFailed:   len(regoReport.ViolationsFileCount()),
-		RulesSkipped:  rulesSkippedCounter,
+		RulesSkipped:  rulesSkipped.Size(),
 		NumViolations: len(regoReport.Violations),
 	}
 
@@ -454,8 +595,14 @@ func (l Linter) Lint(ctx context.Context) (report.Report, error) {
 	}
 
 	if l.profiling && regoReport.AggregateProfile != nil {
+		// AggregateProfile is kept, as it's not part of the JSON report, but may be
+		// used to export the full profile (see reporter.WriteProfile).
 		regoReport.AggregateProfileToSortedProfile(10)
-		regoReport.AggregateProfile = nil
+	}
+
+	if l.profiling {
+		regoReport.AggregateRuleProfileToSortedProfile()
+		regoReport.AggregateRuleProfile = nil
 	}
 
 	return regoReport, nil
@@ -492,6 +639,39 @@ func (l Linter) DetermineEnabledRules(ctx context.Context) ([]string, []string,
 	return getEnabledRules(rs, compiler)
 }
 
+// DetermineEnabledRulesByPreset returns the rules enabled, as determined by DetermineEnabledRules,
+// that were enabled by a preset, mapped to the name of that preset. Rules enabled by the user config,
+// or by options like WithEnabledRules, are not included.
+func (l Linter) DetermineEnabledRulesByPreset(ctx context.Context) (map[string]string, error) {
+	conf, err := l.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge config: %w", err)
+	}
+
+	regular, aggregate, err := l.DetermineEnabledRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[string]string)
+
+	for category, rules := range conf.Rules {
+		for title := range rules {
+			categories[title] = category
+		}
+	}
+
+	byPreset := make(map[string]string)
+
+	for _, title := range slices.Concat(regular, aggregate) {
+		if preset, ok := conf.EnabledByPreset(categories[title], title); ok {
+			byPreset[title] = preset
+		}
+	}
+
+	return byPreset, nil
+}
+
 // GetConfig returns the final configuration for the linter, i.e. Regal's default
 // configuration plus any user-provided configuration merged on top of it.
 func (l Linter) GetConfig() (*config.Config, error) {
@@ -499,7 +679,23 @@ func (l Linter) GetConfig() (*config.Config, error) {
 		return l.combinedCfg, nil
 	}
 
-	mergedConf, err := config.WithDefaultsFromBundle(rbundle.Loaded(), l.userConfig)
+	userConfig := l.userConfig
+
+	if l.preset != "" {
+		var conf config.Config
+		if userConfig != nil {
+			conf = *userConfig
+		}
+
+		withPreset, err := config.WithPreset(conf, l.preset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply preset: %w", err)
+		}
+
+		userConfig = &withPreset
+	}
+
+	mergedConf, err := config.WithDefaultsFromBundle(rbundle.Loaded(), userConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read provided config: %w", err)
 	}
@@ -516,6 +712,22 @@ func (l Linter) GetConfig() (*config.Config, error) {
 	return &mergedConf, nil
 }
 
+// GetConfigForPath returns the configuration in effect for the file at path, i.e. the final
+// configuration of the linter with any overrides matching the path applied.
+func (l Linter) GetConfigForPath(path string) (*config.Config, error) {
+	conf, err := l.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	pathConf, err := conf.ForPath(path, l.pathPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine config for %s: %w", path, err)
+	}
+
+	return &pathConf, nil
+}
+
 func (l Linter) prepareQuery(ctx context.Context) (*rego.PreparedEvalQuery, error) {
 	regoArgs, err := l.prepareRegoArgs(lintQuery)
 	if err != nil {
@@ -536,13 +748,50 @@ func (l Linter) notPrepared() Linter {
 	return l
 }
 
-func (l Linter) validate(conf *config.Config) error {
+// Schema returns the categories and rules known to the linter, including any custom rules, along with the
+// attributes of each rule known from Regal's provided configuration. This may be used to validate user
+// configuration.
+func (l Linter) Schema() (config.Schema, error) {
 	if l.customRuleError != nil {
-		return fmt.Errorf("failed to load custom rules: %w", l.customRuleError)
+		return nil, fmt.Errorf("failed to load custom rules: %w", l.customRuleError)
 	}
 
-	validCategories := util.NewSet[string]()
-	validRules := util.NewSet[string]()
+	return l.schema(), nil
+}
+
+func (l Linter) schema() config.Schema {
+	provided, _ := util.SearchMap(rbundle.Loaded().Data, "regal", "config", "provided", "rules")
+	providedRules, _ := provided.(map[string]any)
+
+	schema := make(config.Schema)
+	add := func(category, title string, module *ast.Module) {
+		if schema[category] == nil {
+			schema[category] = make(map[string][]string)
+		}
+
+		providedCategory, _ := providedRules[category].(map[string]any)
+
+		// attributes of rules not in the provided configuration, like custom rules, can't be known
+		providedRule, ok := providedCategory[title].(map[string]any)
+		if !ok {
+			schema[category][title] = nil
+
+			return
+		}
+
+		// optional attributes are not in the provided configuration, but should be referenced by the rule
+		attributes := util.NewSet(outil.Keys(providedRule)...)
+
+		ast.WalkTerms(module, func(term *ast.Term) bool {
+			if str, ok := term.Value.(ast.String); ok {
+				attributes.Add(string(str))
+			}
+
+			return false
+		})
+
+		schema[category][title] = util.Sorted(append(schema[category][title], attributes.Items()...))
+	}
 
 	// Add all built-in rules
 	for _, b := range l.ruleBundles {
@@ -554,8 +803,7 @@ func (l Linter) validate(conf *config.Config) error {
 				continue
 			}
 
-			validCategories.Add(parts[2])
-			validRules.Add(parts[3])
+			add(parts[2], parts[3], module.Parsed)
 		}
 	}
 
@@ -568,8 +816,23 @@ func (l Linter) validate(conf *config.Config) error {
 			continue
 		}
 
-		validCategories.Add(parts[3])
-		validRules.Add(parts[4])
+		add(parts[3], parts[4], module)
+	}
+
+	return schema
+}
+
+func (l Linter) validate(conf *config.Config) error {
+	if l.customRuleError != nil {
+		return fmt.Errorf("failed to load custom rules: %w", l.customRuleError)
+	}
+
+	schema := l.schema()
+	validCategories := util.NewSet(outil.Keys(schema)...)
+	validRules := util.NewSet[string]()
+
+	for _, rules := range schema {
+		validRules.Add(outil.Keys(rules)...)
 	}
 
 	configuredCategories := util.NewSet(outil.Keys(conf.Rules)...)
@@ -645,6 +908,8 @@ func (l Linter) createDataBundle(conf config.Config) *bundle.Bundle {
 		"enable_all":       l.enableAll,
 		"enable_category":  util.NilSliceToEmpty(l.enableCategory),
 		"enable":           util.NilSliceToEmpty(l.enable),
+		"disable_tag":      util.NilSliceToEmpty(l.disableTag),
+		"enable_tag":       util.NilSliceToEmpty(l.enableTag),
 		"ignore_files":     util.NilSliceToEmpty(l.ignoreFiles),
 	}
 
@@ -661,6 +926,8 @@ func (l Linter) createDataBundle(conf config.Config) *bundle.Bundle {
 				"combined_config": config.ToMap(conf),
 				"capabilities":    rio.ToMap(config.CapabilitiesForThisVersion()),
 				"path_prefix":     l.pathPrefix,
+				"rule_tags":       l.ruleTags(),
+				"custom_rules":    l.customRuleKeys(),
 			},
 		},
 	}
@@ -719,24 +986,71 @@ func (l Linter) lint(ctx context.Context, input rules.Input) (report.Report, err
 	operationCollect := numFiles > 1 || l.useCollectQuery
 
 	// NB(sr): We benchmarked using `wg.SetLimit(runtime.GOMAXPROCS(-1))` here, but performance
-	// got a little worse. So let's not bother.
+	// got a little worse. So let's only limit concurrency when asked to.
 	wg, ctx := errgroup.WithContext(ctx)
+	if l.concurrency > 0 {
+		wg.SetLimit(l.concurrency)
+	}
 
 	results := make([]report.Report, numFiles)
 
+	var handlerMu sync.Mutex
+
+	setResult := func(i int, name string, result report.Report) error {
+		results[i] = result
+
+		if l.resultHandler == nil {
+			return nil
+		}
+
+		handlerMu.Lock()
+		defer handlerMu.Unlock()
+
+		if err := l.resultHandler(FileResult{File: name, Violations: result.Violations, Notices: result.Notices}); err != nil {
+			return fmt.Errorf("result handler failed: %w", err)
+		}
+
+		return nil
+	}
+
 	for i, name := range input.FileNames {
 		wg.Go(func() error {
-			inputValue, err := transform.ToAST(name, input.FileContent[name], input.Modules[name], operationCollect)
+			if err := ctx.Err(); err != nil {
+				// another file failed, so don't bother reading this one
+				return err
+			}
+
+			content, module, err := input.File(name)
+			if err != nil {
+				return fmt.Errorf("errors encountered when reading files to lint: %w", err)
+			}
+
+			var cacheKey string
+
+			// print output from evaluation would be lost on cache hits, so don't use the cache when enabled
+			if l.resultCache != nil && !l.profiling && l.printHook == nil && !l.debugMode {
+				cacheKey = l.resultCache.key(name, content, module.RegoVersion().String(), operationCollect)
+
+				if result, ok := l.resultCache.get(cacheKey); ok {
+					l.incrCounter(regalmetrics.RegalLintCacheHit)
+
+					return setResult(i, name, result)
+				}
+
+				l.incrCounter(regalmetrics.RegalLintCacheMiss)
+			}
+
+			inputValue, err := transform.ToAST(name, content, module, operationCollect)
 			if err != nil {
 				return fmt.Errorf("failed to transform input value: %w", err)
 			}
 
-			evalArgs := []rego.EvalOption{
-				rego.EvalParsedInput(inputValue),
-				rego.EvalInstrument(l.instrumentation),
-				exp.ExternalCancelNoOp,
+			if inputValue, err = l.withOverrides(name, inputValue); err != nil {
+				return err
 			}
 
+			evalArgs := []rego.EvalOption{rego.EvalInstrument(l.instrumentation)}
+
 			if l.baseCache != nil {
 				evalArgs = append(evalArgs, rego.EvalBaseCache(l.baseCache))
 			}
@@ -751,31 +1065,24 @@ func (l Linter) lint(ctx context.Context, input rules.Input) (report.Report, err
 				evalArgs = append(evalArgs, rego.EvalQueryTracer(prof))
 			}
 
-			resultSet, err := l.preparedQuery.Eval(ctx, evalArgs...)
+			result, skipped, err := l.evalFile(ctx, name, inputValue, evalArgs)
 			if err != nil {
-				return fmt.Errorf("error encountered in query evaluation %w", err)
-			}
-
-			result, err := report.FromResultSet(resultSet, false)
-			if err != nil {
-				return fmt.Errorf("failed to convert result set to report: %w", err)
+				return err
 			}
 
 			if l.profiling {
-				// Perhaps we'll want to make this number configurable later, but do note that
-				// this is only the top 10 locations for a *single* file, not the final report.
-				profRep := prof.ReportTopNResults(10, []string{"total_time_ns"})
-
-				result.AggregateProfile = make(map[string]report.ProfileEntry, len(profRep))
+				result.AggregateProfile = l.exprProfile(prof)
+				result.AggregateRuleProfile = l.ruleProfile(prof)
+			}
 
-				for _, rs := range profRep {
-					result.AggregateProfile[rs.Location.String()] = regalmetrics.FromExprStats(rs)
+			// results with rules skipped due to timeouts aren't cached, as the outcome may differ next time
+			if cacheKey != "" && !skipped {
+				if err := l.resultCache.put(cacheKey, result); err != nil && l.debugMode {
+					log.Printf("failed to cache lint result for %s: %v", name, err)
 				}
 			}
 
-			results[i] = result
-
-			return nil
+			return setResult(i, name, result)
 		})
 	}
 
@@ -791,8 +1098,12 @@ func (l Linter) lint(ctx context.Context, input rules.Input) (report.Report, err
 	defer l.stopTimer(regalmetrics.RegalMergeReport)
 
 	regoReport := report.Report{
-		Aggregates:       make(map[string][]report.Aggregate, numFiles),
-		IgnoreDirectives: make(map[string]map[string][]string, numFiles),
+		Aggregates:           make(map[string][]report.Aggregate, numFiles),
+		IgnoreDirectives:     make(map[string]map[string][]string, numFiles),
+		IgnoreRanges:         make(map[string][]report.IgnoreRange, numFiles),
+		UsedIgnoreDirectives: make(map[string]map[string][]string, numFiles),
+		UsedIgnoreRanges:     make(map[string]map[string][]string, numFiles),
+		EvaluatedRules:       make(map[string][]string, numFiles),
 	}
 
 	for i := range results {
@@ -800,7 +1111,7 @@ func (l Linter) lint(ctx context.Context, input rules.Input) (report.Report, err
 		regoReport.Notices = append(regoReport.Notices, results[i].Notices...)
 
 		for k := range results[i].Aggregates {
-			// Custom aggregate rules that have been invoked but not returned any data
+			// Aggregate rules that have been invoked but not returned any data
 			// will return an empty map to signal that they have been called, and that
 			// the aggregate report for this rule should be invoked even when no data
 			// was aggregated. This because the absence of data is exactly what some rules
@@ -820,8 +1131,23 @@ func (l Linter) lint(ctx context.Context, input rules.Input) (report.Report, err
 			regoReport.IgnoreDirectives[k] = results[i].IgnoreDirectives[k]
 		}
 
+		for k := range results[i].IgnoreRanges {
+			regoReport.IgnoreRanges[k] = results[i].IgnoreRanges[k]
+		}
+
+		for k := range results[i].UsedIgnoreDirectives {
+			regoReport.UsedIgnoreDirectives[k] = results[i].UsedIgnoreDirectives[k]
+		}
+
+		for k := range results[i].UsedIgnoreRanges {
+			regoReport.UsedIgnoreRanges[k] = results[i].UsedIgnoreRanges[k]
+		}
+
+		maps.Copy(regoReport.EvaluatedRules, results[i].EvaluatedRules)
+
 		if l.profiling {
 			regoReport.AddProfileEntries(results[i].AggregateProfile)
+			regoReport.AddRuleProfileEntries(results[i].AggregateRuleProfile)
 		}
 	}
 
@@ -832,6 +1158,7 @@ func (l Linter) lintWithAggregateRules(
 	ctx context.Context,
 	aggregates map[string][]report.Aggregate,
 	ignoreDirectives map[string]map[string][]string,
+	ignoreRanges map[string][]report.IgnoreRange,
 ) (report.Report, error) {
 	l.startTimer(regalmetrics.RegalLintRegoAggregate)
 	defer l.stopTimer(regalmetrics.RegalLintRegoAggregate)
@@ -839,7 +1166,7 @@ func (l Linter) lintWithAggregateRules(
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	regal := ast.ObjectTerm(
+	regal := ast.NewObject(
 		ast.Item(ast.InternedTerm("operations"), ast.ArrayTerm(ast.InternedTerm("aggregate"))),
 		ast.Item(ast.InternedTerm("file"), ast.ObjectTerm(
 			ast.Item(ast.InternedTerm("name"), ast.InternedTerm("__aggregate_report__")),
@@ -847,13 +1174,24 @@ func (l Linter) lintWithAggregateRules(
 		)),
 	)
 
+	overrides, err := l.aggregateOverrides(aggregates, ignoreDirectives)
+	if err != nil {
+		return report.Report{}, err
+	}
+
+	if overrides != nil {
+		regal.Insert(ast.InternedTerm("config"), ast.ObjectTerm(ast.Item(ast.InternedTerm("files"), ast.NewTerm(overrides))))
+	}
+
 	aggParsed, _ := transform.ToOPAInputValue(aggregates)
 	dirParsed, _ := transform.ToOPAInputValue(ignoreDirectives)
+	rangesParsed, _ := transform.ToOPAInputValue(ignoreRanges)
 
 	inputValue := ast.NewObject(
 		ast.Item(ast.InternedTerm("aggregates_internal"), ast.NewTerm(aggParsed)),
 		ast.Item(ast.InternedTerm("ignore_directives"), ast.NewTerm(dirParsed)),
-		ast.Item(ast.InternedTerm("regal"), regal),
+		ast.Item(ast.InternedTerm("ignore_ranges"), ast.NewTerm(rangesParsed)),
+		ast.Item(ast.InternedTerm("regal"), ast.NewTerm(regal)),
 	)
 
 	evalArgs := []rego.EvalOption{
@@ -887,13 +1225,8 @@ func (l Linter) lintWithAggregateRules(
 	}
 
 	if l.profiling {
-		profRep := prof.ReportTopNResults(10, []string{"total_time_ns"})
-
-		result.AggregateProfile = make(map[string]report.ProfileEntry, len(profRep))
-
-		for _, rs := range profRep {
-			result.AggregateProfile[rs.Location.String()] = regalmetrics.FromExprStats(rs)
-		}
+		result.AggregateProfile = l.exprProfile(prof)
+		result.AggregateRuleProfile = l.ruleProfile(prof)
 	}
 
 	return result, nil
@@ -910,3 +1243,9 @@ func (l Linter) stopTimer(name string) {
 		l.metrics.Timer(name).Stop()
 	}
 }
+
+func (l Linter) incrCounter(name string) {
+	if l.metrics != nil {
+		l.metrics.Counter(name).Incr()
+	}
+}
@@ -3,12 +3,21 @@ package linter
 import (
 	"bytes"
 	"embed"
+	"errors"
+	"fmt"
+	"maps"
 	"path/filepath"
+	"reflect"
 	"slices"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
+	"github.com/open-policy-agent/opa/v1/metrics"
 	"github.com/open-policy-agent/opa/v1/topdown"
 
+	regalmetrics "github.com/open-policy-agent/regal/internal/metrics"
 	"github.com/open-policy-agent/regal/internal/parse"
 	"github.com/open-policy-agent/regal/internal/test"
 	"github.com/open-policy-agent/regal/internal/testutil"
@@ -310,6 +319,80 @@ func TestLintWithCustomRule(t *testing.T) {
 	}
 }
 
+func TestLintWithTags(t *testing.T) {
+	t.Parallel()
+
+	rulesDir := testutil.TempDirectoryOf(t, map[string]string{"custom.rego": `# METADATA
+# description: All packages must use "acme" base name
+# custom:
+#   tags: [security]
+package custom.regal.rules.naming["acme-package"]
+
+import data.regal.result
+
+report contains violation if {
+	input.package.path[1].value != "acme"
+
+	violation := result.fail(rego.metadata.chain(), result.location(input.package.path[1]))
+}
+`})
+
+	testCases := map[string]struct {
+		linter   Linter
+		expected map[string][]string
+	}{
+		"enabled tag": {
+			linter: NewLinter().WithDisableAll(true).WithEnabledTags("security"),
+			expected: map[string][]string{
+				"acme-package": {"security"},
+			},
+		},
+		"enabled tags": {
+			linter: NewLinter().WithDisableAll(true).WithEnabledTags("security", "autofixable"),
+			expected: map[string][]string{
+				"acme-package":            {"security"},
+				"opa-fmt":                 {"autofixable"},
+				"use-assignment-operator": {"autofixable"},
+			},
+		},
+		"disabled tag": {
+			linter: NewLinter().WithDisabledTags("security", "autofixable"),
+			expected: map[string][]string{
+				"rule-name-repeats-package": nil,
+			},
+		},
+		"disabled tag with enabled rule": {
+			linter: NewLinter().WithDisabledTags("security").WithEnabledRules("acme-package"),
+			expected: map[string][]string{
+				"acme-package":              {"security"},
+				"opa-fmt":                   {"autofixable"},
+				"rule-name-repeats-package": nil,
+				"use-assignment-operator":   {"autofixable"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result := testutil.Must(tc.linter.
+				WithCustomRules([]string{rulesDir}).
+				WithInputModules(test.InputPolicy("p/p.rego", "package p\n\np = 1\n")).
+				Lint(t.Context()))(t)
+
+			tags := make(map[string][]string, len(result.Violations))
+			for _, violation := range result.Violations {
+				tags[violation.Title] = violation.Tags
+			}
+
+			if !reflect.DeepEqual(tags, tc.expected) {
+				t.Errorf("expected violations with tags %v, got %v", tc.expected, tags)
+			}
+		})
+	}
+}
+
 func TestLintWithErrorInEnable(t *testing.T) {
 	t.Parallel()
 
@@ -483,6 +566,167 @@ func TestLintWithAggregateRule(t *testing.T) {
 	}
 }
 
+func TestLintWithIgnoreRanges(t *testing.T) {
+	t.Parallel()
+
+	policies := map[string]string{
+		"foo.rego": "package foo\n\nimport data.bar\n\ndefault allow := false\n",
+		"bar.rego": `package bar
+
+# regal ignore-start:prefer-package-imports,prefer-snake-case
+import data.foo.allow
+
+camelCase := true
+# regal ignore-end
+
+otherCamelCase := true
+`,
+	}
+
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("prefer-package-imports", "prefer-snake-case").
+		WithInputModules(&input)
+
+	result := testutil.Must(linter.Lint(t.Context()))(t)
+
+	testutil.AssertNumViolations(t, 1, result)
+
+	if violation := result.Violations[0]; violation.Title != "prefer-snake-case" || violation.Location.Row != 9 {
+		t.Errorf("expected prefer-snake-case violation on line 9, got %s on line %d", violation.Title, violation.Location.Row)
+	}
+
+	expected := []report.IgnoreRange{{Rules: []string{"prefer-package-imports", "prefer-snake-case"}, Start: 3, End: 7}}
+	if !reflect.DeepEqual(expected, result.IgnoreRanges["bar.rego"]) {
+		t.Errorf("expected ignore ranges %v, got %v", expected, result.IgnoreRanges["bar.rego"])
+	}
+}
+
+func TestLintWithReportUnusedIgnores(t *testing.T) {
+	t.Parallel()
+
+	policies := map[string]string{
+		"foo.rego": `package foo
+
+# regal ignore:unresolved-import
+import data.bar.missing
+
+# regal ignore:unresolved-import
+import data.bar.x
+
+# regal ignore:prefer-snake-case
+camelCase := x
+
+# regal ignore:prefer-snake-case,line-lenght
+snake_case := missing
+
+# regal ignore:opa-fmt
+fmt := true
+`,
+		"bar.rego": `package bar
+
+# regal ignore-start:prefer-snake-case
+x := 1
+# regal ignore-end
+`,
+	}
+
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("unresolved-import", "prefer-snake-case").
+		WithReportUnusedIgnores(true).
+		WithInputModules(&input)
+
+	result := testutil.Must(linter.Lint(t.Context()))(t)
+
+	got := make([]string, 0, len(result.Violations))
+	for _, violation := range result.Violations {
+		got = append(got, fmt.Sprintf("%s:%d %s", violation.Location.File, violation.Location.Row, violation.Description))
+	}
+
+	expected := []string{
+		"foo.rego:6 Ignore directive for unresolved-import is unused",
+		"foo.rego:12 Ignore directive for prefer-snake-case is unused",
+		"foo.rego:12 Ignore directive for unknown rule line-lenght",
+		"bar.rego:3 Ignore directive for prefer-snake-case is unused",
+	}
+
+	slices.Sort(got)
+	slices.Sort(expected)
+
+	if !slices.Equal(expected, got) {
+		t.Errorf("expected violations:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	if result.UsedIgnoreDirectives != nil || result.UsedIgnoreRanges != nil {
+		t.Errorf("expected used ignore directives to be left out of the final report")
+	}
+}
+
+func TestLintUnusedIgnoreDirectiveRule(t *testing.T) {
+	t.Parallel()
+
+	policy := "package p\n\n# regal ignore:prefer-snake-case\nsnake_case := 1\n"
+	policies := map[string]string{
+		"p/p.rego":           policy,
+		"legacy/p.rego":      policy,
+		"vendor/p.rego":      policy,
+		"inline/p.rego":      "# regal disable-file:prefer-snake-case\n" + policy,
+		"unreported/p.rego":  policy,
+		"warning/sub/p.rego": policy,
+	}
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	userConfig := testutil.MustUnmarshalYAML[config.Config](t, []byte(`rules:
+  default:
+    level: ignore
+  style:
+    prefer-snake-case:
+      level: error
+      ignore:
+        files:
+          - vendor/**
+    unused-ignore-directive:
+      level: error
+      ignore:
+        files:
+          - unreported/**
+overrides:
+  - files:
+      - legacy/**
+    rules:
+      style:
+        prefer-snake-case:
+          level: ignore
+  - files:
+      - warning/**
+    rules:
+      style:
+        unused-ignore-directive:
+          level: warning
+`))
+
+	result := testutil.Must(NewLinter().WithUserConfig(userConfig).WithInputModules(&input).Lint(t.Context()))(t)
+
+	got := make([]string, 0, len(result.Violations))
+	for _, violation := range result.Violations {
+		got = append(got, fmt.Sprintf("%s:%s:%s", violation.Location.File, violation.Title, violation.Level))
+	}
+
+	// prefer-snake-case is disabled for the other files, so whether the directive is needed there can't be known
+	expected := []string{"p/p.rego:unused-ignore-directive:error", "warning/sub/p.rego:unused-ignore-directive:warning"}
+
+	slices.Sort(got)
+
+	if !slices.Equal(expected, got) {
+		t.Errorf("expected violations %v, got %v", expected, got)
+	}
+}
+
 func TestEnabledRules(t *testing.T) {
 	t.Parallel()
 
@@ -633,3 +877,484 @@ import data.unresolved`,
 		t.Fatalf("unexpected files: %v", got)
 	}
 }
+
+func TestLintWithResultCache(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := t.TempDir()
+	policies := map[string]string{
+		"foo.rego": "package foo\n\nimport data.bar\n\ncamelCase := true\n",
+		"bar.rego": "package bar\n\nimport data.foo.camelCase\n",
+	}
+
+	lint := func(policies map[string]string) (report.Report, map[string]any) {
+		input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+		m := metrics.New()
+
+		linter := NewLinter().
+			WithDisableAll(true).
+			WithEnabledRules("prefer-snake-case", "prefer-package-imports").
+			WithCacheDir(cacheDir).
+			WithMetrics(m).
+			WithInputModules(&input)
+
+		return testutil.Must(linter.Lint(t.Context()))(t), m.All()
+	}
+
+	first, firstMetrics := lint(policies)
+
+	testutil.AssertOnlyViolations(t, first, "prefer-snake-case", "prefer-package-imports")
+
+	if misses := firstMetrics["counter_"+regalmetrics.RegalLintCacheMiss]; misses != uint64(2) {
+		t.Errorf("expected 2 cache misses on first run, got %v", misses)
+	}
+
+	second, secondMetrics := lint(policies)
+
+	if hits := secondMetrics["counter_"+regalmetrics.RegalLintCacheHit]; hits != uint64(2) {
+		t.Errorf("expected 2 cache hits on second run, got %v", hits)
+	}
+
+	if len(first.Violations) != len(second.Violations) {
+		t.Fatalf("expected cached run to report %d violations, got %d", len(first.Violations), len(second.Violations))
+	}
+
+	// changing one file should have only that file linted again, while aggregate
+	// data for the unchanged file is read from the cache
+	policies["foo.rego"] = "package foo\n\nimport data.bar\n\nsnake_case := true\n"
+
+	third, thirdMetrics := lint(policies)
+
+	if hits := thirdMetrics["counter_"+regalmetrics.RegalLintCacheHit]; hits != uint64(1) {
+		t.Errorf("expected 1 cache hit on third run, got %v", hits)
+	}
+
+	testutil.AssertOnlyViolations(t, third, "prefer-package-imports")
+}
+
+func TestResultCacheSaltStableWithCustomRules(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		fsys["custom/regal/rules/custom/"+name+".rego"] = &fstest.MapFile{
+			Data: []byte("package custom.regal.rules.custom." + name + "\n\nreport := set()\n"),
+		}
+	}
+
+	var salt []byte
+
+	for range 5 {
+		linter := NewLinter().WithCustomRulesFromFS(fsys, "custom").WithCacheDir(t.TempDir())
+		linter = testutil.Must(linter.Prepare(t.Context()))(t)
+
+		if salt != nil && !bytes.Equal(salt, linter.resultCache.salt) {
+			t.Fatal("expected the same cache salt for the same custom rules")
+		}
+
+		salt = linter.resultCache.salt
+	}
+}
+
+func TestResultCacheKeyExpiresWithIgnoreDirectiveUntil(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2030, 1, 31, 23, 0, 0, 0, time.UTC)
+	cache := &resultCache{dir: t.TempDir(), now: func() time.Time { return day }}
+
+	expiring := "package p\n\n# regal ignore:prefer-snake-case until=2030-01-31\ncamelCase := 1\n"
+	plain := "package p\n\n# regal ignore:prefer-snake-case\ncamelCase := 1\n"
+
+	expiringKey, plainKey := cache.key("p.rego", expiring, "v1", false), cache.key("p.rego", plain, "v1", false)
+
+	cache.now = func() time.Time { return day.Add(2 * time.Hour) }
+
+	if cache.key("p.rego", expiring, "v1", false) == expiringKey {
+		t.Error("expected a different key on the next day for a file with an expiring ignore directive")
+	}
+
+	if cache.key("p.rego", plain, "v1", false) != plainKey {
+		t.Error("expected the same key on the next day for a file without an expiring ignore directive")
+	}
+}
+
+func TestLintWithFileResultHandler(t *testing.T) {
+	t.Parallel()
+
+	policies := map[string]string{
+		"foo.rego": "package foo\n\nimport data.bar\n\ncamelCase := true\n",
+		"bar.rego": "package bar\n\nimport data.foo.camelCase\n",
+	}
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	var results []FileResult
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("prefer-snake-case", "prefer-package-imports").
+		WithInputModules(&input).
+		WithFileResultHandler(func(result FileResult) error {
+			results = append(results, result)
+
+			return nil
+		})
+
+	result := testutil.Must(linter.Lint(t.Context()))(t)
+
+	testutil.AssertOnlyViolations(t, result, "prefer-snake-case", "prefer-package-imports")
+
+	if len(results) != 3 {
+		t.Fatalf("expected 2 file results and 1 aggregate result, got %d", len(results))
+	}
+
+	violations := 0
+
+	for i, fr := range results[:2] {
+		if fr.Aggregate || !slices.Contains(input.FileNames, fr.File) {
+			t.Errorf("expected result %d to be for a linted file, got %+v", i, fr)
+		}
+
+		violations += len(fr.Violations)
+	}
+
+	if violations != 1 {
+		t.Errorf("expected 1 violation from non-aggregate rules, got %d", violations)
+	}
+
+	if last := results[2]; !last.Aggregate || len(last.Violations) != 1 || last.Violations[0].Title != "prefer-package-imports" {
+		t.Errorf("expected last result to be aggregate violation, got %+v", last)
+	}
+
+	stop := errors.New("stop")
+
+	_, err := linter.WithFileResultHandler(func(FileResult) error { return stop }).Lint(t.Context())
+	if !errors.Is(err, stop) {
+		t.Errorf("expected error from handler to abort linting, got %v", err)
+	}
+}
+
+func TestLintWithConcurrency(t *testing.T) {
+	t.Parallel()
+
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"foo/foo.rego": "package foo\n\nimport data.bar\n\ncamelCase := true\n",
+		"bar/bar.rego": "package bar\n\nimport data.foo.camelCase\n",
+	})
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("prefer-snake-case", "prefer-package-imports").
+		WithInputPaths([]string{root})
+
+	unbounded := testutil.Must(linter.Lint(t.Context()))(t)
+	bounded := testutil.Must(linter.WithConcurrency(1).Lint(t.Context()))(t)
+
+	testutil.AssertOnlyViolations(t, bounded, "prefer-snake-case", "prefer-package-imports")
+
+	if bounded.Summary != unbounded.Summary {
+		t.Errorf("expected same summary with bounded concurrency, got %+v and %+v", bounded.Summary, unbounded.Summary)
+	}
+}
+
+func TestLintWithProfilingAttributesTimeToRules(t *testing.T) {
+	t.Parallel()
+
+	policies := map[string]string{
+		"foo.rego": "package foo\n\nimport data.bar\n\ncamelCase := true\n",
+		"bar.rego": "package bar\n\nimport data.foo.camelCase\n",
+	}
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("prefer-snake-case", "prefer-package-imports", "acme-corp-package").
+		WithCustomRules([]string{filepath.Join("testdata", "custom.rego")}).
+		WithProfiling(true).
+		WithInputModules(&input)
+
+	result := testutil.Must(linter.Lint(t.Context()))(t)
+
+	expected := map[string]int{
+		"style/prefer-snake-case":        1,
+		"imports/prefer-package-imports": 1, // aggregate rule
+		"naming/acme-corp-package":       2, // custom rule
+	}
+
+	if len(result.RuleProfile) != len(expected) {
+		t.Fatalf("expected %d rules in profile, got %+v", len(expected), result.RuleProfile)
+	}
+
+	for _, entry := range result.RuleProfile {
+		numViolations, ok := expected[entry.Category+"/"+entry.Title]
+		if !ok {
+			t.Errorf("unexpected rule in profile: %+v", entry)
+
+			continue
+		}
+
+		if entry.NumViolations != numViolations || entry.NumEval == 0 || entry.TotalTimeNs == 0 {
+			t.Errorf("expected %d violations and evaluation stats for %s, got %+v", numViolations, entry.Title, entry)
+		}
+	}
+
+	if len(result.CategoryProfile) != 3 {
+		t.Errorf("expected 3 categories in profile, got %+v", result.CategoryProfile)
+	}
+}
+
+func TestLintWithRuleTimeout(t *testing.T) {
+	t.Parallel()
+
+	slowRule := func(title string) string {
+		return `# METADATA
+# description: Takes forever
+package custom.regal.rules.testing["` + title + `"]
+
+import data.regal.result
+
+report contains violation if {
+	some i in numbers.range(1, 10000)
+	some j in numbers.range(1, 10000)
+	i + j < 0
+
+	violation := result.fail(rego.metadata.chain(), {})
+}
+`
+	}
+
+	rulesDir := testutil.TempDirectoryOf(t, map[string]string{
+		"slow.rego":   slowRule("slow-rule"),
+		"slower.rego": slowRule("slower-rule"),
+	})
+
+	policies := map[string]string{
+		"p/p.rego": "package p\n\ncamelCase := true\n",
+		"q/q.rego": "package q\n\ncamelCase := true\n",
+	}
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("prefer-snake-case", "slow-rule", "slower-rule").
+		WithCustomRules([]string{rulesDir}).
+		WithRuleTimeout(time.Second).
+		WithInputModules(&input)
+
+	result := testutil.Must(linter.Lint(t.Context()))(t)
+
+	testutil.AssertOnlyViolations(t, result, "prefer-snake-case")
+
+	// the slower rule is skipped without being evaluated, as the first slow rule uses up
+	// the budget shared by all rules once evaluated one at a time
+	if len(result.Notices) != 4 {
+		t.Fatalf("expected 4 notices, got %+v", result.Notices)
+	}
+
+	for _, notice := range result.Notices {
+		if notice.Category != "testing" || !strings.HasPrefix(notice.Title, "slow") {
+			t.Errorf("expected notice about slow rules, got %+v", notice)
+		}
+
+		if !strings.Contains(notice.Description, "p/p.rego") && !strings.Contains(notice.Description, "q/q.rego") {
+			t.Errorf("expected notice to name the file, got %q", notice.Description)
+		}
+	}
+
+	if result.Summary.RulesSkipped != 2 {
+		t.Errorf("expected 2 rules skipped, got %d", result.Summary.RulesSkipped)
+	}
+}
+
+func TestLintWithOverrides(t *testing.T) {
+	t.Parallel()
+
+	policy := "package p\n\ncamelCase := \"" + strings.Repeat("a", 90) + "\"\n"
+	policies := map[string]string{
+		"p/p.rego":          policy,
+		"tests/p_test.rego": policy,
+		"vendor/lib/v.rego": policy,
+		"vendor/lib/w.rego": policy,
+		"other/vendor.rego": policy,
+	}
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	userConfig := testutil.MustUnmarshalYAML[config.Config](t, []byte(`rules:
+  style:
+    line-length:
+      max-line-length: 80
+  idiomatic:
+    directory-package-mismatch:
+      level: ignore
+overrides:
+  - files:
+      - tests/**
+    rules:
+      style:
+        line-length:
+          max-line-length: 120
+  - files:
+      - vendor/**
+    rules:
+      style:
+        line-length:
+          level: warning
+        prefer-snake-case:
+          level: ignore
+  - files:
+      - w.rego
+    rules:
+      idiomatic:
+        directory-package-mismatch:
+          level: error
+`))
+
+	result := testutil.Must(NewLinter().WithUserConfig(userConfig).WithInputModules(&input).Lint(t.Context()))(t)
+
+	got := make(map[string][]string)
+
+	for _, violation := range result.Violations {
+		got[violation.Location.File] = append(got[violation.Location.File], violation.Title+":"+violation.Level)
+	}
+
+	for file := range got {
+		slices.Sort(got[file])
+	}
+
+	expected := map[string][]string{
+		"p/p.rego":          {"line-length:error", "prefer-snake-case:error"},
+		"other/vendor.rego": {"line-length:error", "prefer-snake-case:error"},
+		"vendor/lib/v.rego": {"line-length:warning"},
+		"vendor/lib/w.rego": {"directory-package-mismatch:error", "line-length:warning"},
+		"tests/p_test.rego": {"prefer-snake-case:error"},
+	}
+
+	for file, exp := range expected {
+		if !slices.Equal(exp, got[file]) {
+			t.Errorf("expected violations %v for %s, got %v", exp, file, got[file])
+		}
+	}
+
+	enabledRules, _, err := NewLinter().WithUserConfig(userConfig).DetermineEnabledRules(t.Context())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !slices.Contains(enabledRules, "directory-package-mismatch") {
+		t.Errorf("expected directory-package-mismatch, enabled by override, to be in enabled rules")
+	}
+}
+
+func TestLintWithOverridesForAggregateRules(t *testing.T) {
+	t.Parallel()
+
+	policies := map[string]string{
+		"p/p.rego":      "package p\n\nimport data.q.missing\n",
+		"p/q.rego":      "package q\n\nx := 1\n",
+		"vendor/v.rego": "package v\n\nimport data.q.missing\n",
+	}
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	userConfig := testutil.MustUnmarshalYAML[config.Config](t, []byte(`rules:
+  default:
+    level: ignore
+  imports:
+    unresolved-import:
+      level: error
+overrides:
+  - files:
+      - p/**
+    rules:
+      idiomatic:
+        no-defined-entrypoint:
+          level: warning
+  - files:
+      - vendor/**
+    rules:
+      imports:
+        unresolved-import:
+          level: ignore
+`))
+
+	result := testutil.Must(NewLinter().WithUserConfig(userConfig).WithInputModules(&input).Lint(t.Context()))(t)
+
+	got := make([]string, 0, len(result.Violations))
+	for _, violation := range result.Violations {
+		got = append(got, fmt.Sprintf("%s:%s:%s", violation.Location.File, violation.Title, violation.Level))
+	}
+
+	slices.Sort(got)
+
+	expected := []string{":no-defined-entrypoint:warning", "p/p.rego:unresolved-import:error"}
+
+	if !slices.Equal(expected, got) {
+		t.Errorf("expected violations %v, got %v", expected, got)
+	}
+}
+
+func TestEnabledRulesWithPreset(t *testing.T) {
+	t.Parallel()
+
+	userConfig := testutil.MustUnmarshalYAML[config.Config](t, []byte(`rules:
+  style:
+    opa-fmt:
+      level: error
+`))
+
+	linter := NewLinter().
+		WithPreset("rego-v1-migration").
+		WithUserConfig(userConfig).
+		WithEnabledCategories("testing").
+		WithDisabledRules("use-if")
+
+	enabledRules, _, err := linter.DetermineEnabledRules(t.Context())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, rule := range []string{"use-contains", "opa-fmt", "print-or-trace-call"} {
+		if !slices.Contains(enabledRules, rule) {
+			t.Errorf("expected %s to be in enabled rules, got %v", rule, enabledRules)
+		}
+	}
+
+	for _, rule := range []string{"use-if", "line-length", "prefer-snake-case"} {
+		if slices.Contains(enabledRules, rule) {
+			t.Errorf("did not expect %s to be in enabled rules", rule)
+		}
+	}
+
+	byPreset, err := linter.DetermineEnabledRulesByPreset(t.Context())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// opa-fmt is enabled by the user config, and print-or-trace-call by the enabled category
+	expected := map[string]string{
+		"deprecated-builtin":       "rego-v1-migration",
+		"implicit-future-keywords": "rego-v1-migration",
+		"rule-named-if":            "rego-v1-migration",
+		"use-contains":             "rego-v1-migration",
+		"use-rego-v1":              "rego-v1-migration",
+	}
+
+	if !maps.Equal(expected, byPreset) {
+		t.Errorf("expected rules enabled by preset %v, got %v", expected, byPreset)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := testutil.Must(NewLinter().WithCustomRules([]string{filepath.Join("testdata", "custom.rego")}).Schema())(t)
+
+	// optional attributes, like non-breakable-word-threshold, are not in the provided configuration
+	for _, attribute := range []string{"max-line-length", "non-breakable-word-threshold"} {
+		if !slices.Contains(schema["style"]["line-length"], attribute) {
+			t.Errorf("expected %s to be a known attribute of line-length, got %v", attribute, schema["style"]["line-length"])
+		}
+	}
+
+	if attributes, ok := schema["naming"]["acme-corp-package"]; !ok || attributes != nil {
+		t.Errorf("expected custom rule to be known, with any attributes accepted, got %v", attributes)
+	}
+}
@@ -0,0 +1,100 @@
+package linter
+
+import (
+	"fmt"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/config"
+	"github.com/open-policy-agent/regal/pkg/report"
+	"github.com/open-policy-agent/regal/pkg/roast/transform"
+)
+
+// withOverrides sets the rules configuration in effect for the named file as regal.config.rules
+// in its input value, if any overrides in the configuration match the file. Rules then consult
+// that rather than the combined configuration provided in data.
+func (l Linter) withOverrides(name string, inputValue ast.Value) (ast.Value, error) {
+	rules, err := l.rulesWithOverrides(name)
+	if err != nil || rules == nil {
+		return inputValue, err
+	}
+
+	//nolint:forcetypeassert
+	regal := inputValue.(ast.Object).Get(ast.InternedTerm("regal")).Value.(ast.Object)
+	regal.Insert(ast.InternedTerm("config"), ast.ObjectTerm(ast.Item(ast.InternedTerm("rules"), ast.NewTerm(rules))))
+
+	return inputValue, nil
+}
+
+// aggregateOverrides returns the rules configuration in effect for each of the files linted, or
+// aggregated from, that any overrides in the configuration match, keyed by file name, for the
+// aggregate rules to report violations in those files according to the overrides. Returns nil if
+// no overrides match any of the files.
+func (l Linter) aggregateOverrides(
+	aggregates map[string][]report.Aggregate,
+	ignoreDirectives map[string]map[string][]string,
+) (ast.Value, error) {
+	if l.combinedCfg == nil || len(l.combinedCfg.Overrides) == 0 {
+		return nil, nil
+	}
+
+	files := util.NewSet[string]()
+
+	for file := range ignoreDirectives {
+		files.Add(file)
+	}
+
+	for _, entries := range aggregates {
+		for _, entry := range entries {
+			if file := entry.SourceFile(); file != "" {
+				files.Add(file)
+			}
+		}
+	}
+
+	overrides := ast.NewObject()
+
+	for file := range files.Values() {
+		rules, err := l.rulesWithOverrides(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if rules != nil {
+			overrides.Insert(ast.InternedTerm(file), ast.ObjectTerm(ast.Item(ast.InternedTerm("rules"), ast.NewTerm(rules))))
+		}
+	}
+
+	if overrides.Len() == 0 {
+		return nil, nil
+	}
+
+	return overrides, nil
+}
+
+// rulesWithOverrides returns the rules configuration in effect for the named file, or nil if no
+// overrides in the configuration match the file.
+func (l Linter) rulesWithOverrides(name string) (ast.Value, error) {
+	if l.combinedCfg == nil || len(l.combinedCfg.Overrides) == 0 {
+		return nil, nil
+	}
+
+	overrides, err := l.combinedCfg.MatchingOverrides(name, l.pathPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match overrides for %s: %w", name, err)
+	}
+
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+
+	confMap := config.ToMap(l.combinedCfg.WithOverridesApplied(overrides...))
+
+	rules, err := transform.AnyToValue(confMap["rules"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert rules configuration for %s: %w", name, err)
+	}
+
+	return rules, nil
+}
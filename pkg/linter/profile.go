@@ -0,0 +1,110 @@
+package linter
+
+import (
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/profiler"
+
+	regalmetrics "github.com/open-policy-agent/regal/internal/metrics"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// ruleFiles maps the file name of each rule module, built-in or custom, to the
+// category/title of the rule, so that profiling data can be attributed to rules.
+func (l Linter) ruleFiles() map[string]string {
+	files := make(map[string]string)
+
+	add := func(module *ast.Module) {
+		if module == nil || module.Package == nil || module.Package.Location == nil {
+			return
+		}
+
+		if key, ok := ruleKey(module.Package.Path); ok {
+			files[module.Package.Location.File] = key
+		}
+	}
+
+	for _, b := range l.ruleBundles {
+		for _, mf := range b.Modules {
+			add(mf.Parsed)
+		}
+	}
+
+	for _, m := range l.customRuleModules {
+		add(m)
+	}
+
+	return files
+}
+
+// ruleKey returns the category/title of the rule declared by a package path of either
+// data.regal.rules.<category>.<title> or data.custom.regal.rules.<category>.<title>.
+func ruleKey(path ast.Ref) (string, bool) {
+	parts := make([]string, 0, len(path))
+
+	for _, term := range path[1:] {
+		s, ok := term.Value.(ast.String)
+		if !ok {
+			return "", false
+		}
+
+		parts = append(parts, string(s))
+	}
+
+	if len(parts) == 5 && parts[0] == "custom" {
+		parts = parts[1:]
+	}
+
+	if len(parts) != 4 || parts[0] != "regal" || parts[1] != "rules" {
+		return "", false
+	}
+
+	return parts[2] + "/" + parts[3], true
+}
+
+// exprProfile returns the profiling data of all expressions evaluated, keyed by location,
+// and with the rule declared in the file of the expression, if any.
+func (l Linter) exprProfile(prof *profiler.Profiler) map[string]report.ProfileEntry {
+	stats := prof.ReportTopNResults(0, nil)
+	entries := make(map[string]report.ProfileEntry, len(stats))
+
+	for _, rs := range stats {
+		entry := regalmetrics.FromExprStats(rs)
+		entry.Rule = l.ruleFileNames[rs.Location.File]
+
+		entries[entry.Location] = entry
+	}
+
+	return entries
+}
+
+// ruleProfile attributes the time spent and the number of expressions evaluated to the rule
+// declared in the file of each expression. Expressions in modules not declaring rules, like
+// the shared Regal library or the main module, are not included.
+func (l Linter) ruleProfile(prof *profiler.Profiler) map[string]report.RuleProfileEntry {
+	entries := make(map[string]report.RuleProfileEntry)
+
+	for file, fileReport := range prof.ReportByFile().Files {
+		key, ok := l.ruleFileNames[file]
+		if !ok {
+			continue
+		}
+
+		entry := entries[key]
+
+		for _, stats := range fileReport.Result {
+			entry.TotalTimeNs += stats.ExprTimeNs
+			entry.NumEval += stats.NumEval
+		}
+
+		entries[key] = entry
+	}
+
+	for key, entry := range entries {
+		entry.Category, entry.Title, _ = strings.Cut(key, "/")
+		entries[key] = entry
+	}
+
+	return entries
+}
@@ -0,0 +1,74 @@
+package linter
+
+import (
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+)
+
+// ruleTags returns the tags declared under custom.tags in the package metadata of each rule,
+// including custom rules, keyed by category and title.
+func (l Linter) ruleTags() map[string]any {
+	tags := make(map[string]any)
+
+	add := func(module *ast.Module) {
+		if module == nil || module.Package == nil {
+			return
+		}
+
+		key, ok := ruleKey(module.Package.Path)
+		if !ok {
+			return
+		}
+
+		for _, annotation := range module.Annotations {
+			if annotation.Scope != "package" {
+				continue
+			}
+
+			ruleTags := tagsFromCustom(annotation.Custom)
+			if len(ruleTags) == 0 {
+				continue
+			}
+
+			category, title, _ := strings.Cut(key, "/")
+
+			rules, ok := tags[category].(map[string]any)
+			if !ok {
+				rules = make(map[string]any)
+				tags[category] = rules
+			}
+
+			rules[title] = ruleTags
+		}
+	}
+
+	for _, b := range l.ruleBundles {
+		for _, mf := range b.Modules {
+			add(mf.Parsed)
+		}
+	}
+
+	for _, m := range l.customRuleModules {
+		add(m)
+	}
+
+	return tags
+}
+
+func tagsFromCustom(custom map[string]any) []any {
+	values, ok := custom["tags"].([]any)
+	if !ok {
+		return nil
+	}
+
+	tags := make([]any, 0, len(values))
+
+	for _, value := range values {
+		if tag, ok := value.(string); ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
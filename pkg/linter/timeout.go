@@ -0,0 +1,188 @@
+package linter
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/open-policy-agent/opa/v1/topdown"
+
+	"github.com/open-policy-agent/regal/internal/exp"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// customRulesRef is the package path prefix of custom rules.
+var customRulesRef = ast.MustParseRef("data.custom.regal.rules")
+
+// evalFile evaluates the lint query for a single file. When a rule timeout is set and the evaluation
+// exceeds it, the rules enabled for the file are evaluated again one at a time, sharing a second budget
+// of the same length, and any rule not evaluated before that budget runs out is skipped for the file,
+// and reported in a notice. Evaluating a file thus takes at most about twice the rule timeout. The
+// returned bool is true if any rule was skipped.
+func (l Linter) evalFile(
+	ctx context.Context,
+	name string,
+	inputValue ast.Value,
+	evalArgs []rego.EvalOption,
+) (report.Report, bool, error) {
+	result, timedOut, err := l.evalWithTimeout(ctx, inputValue, evalArgs, l.ruleTimeout)
+	if err != nil || !timedOut {
+		return result, false, err
+	}
+
+	deadline := time.Now().Add(l.ruleTimeout)
+
+	//nolint:forcetypeassert
+	input := inputValue.(ast.Object)
+	//nolint:forcetypeassert
+	regal := input.Get(ast.InternedTerm("regal")).Value.(ast.Object)
+	withRule := func(rule string) ast.Object {
+		return withAttribute(input, "regal", ast.NewTerm(withAttribute(regal, "rule", ast.StringTerm(rule))))
+	}
+
+	// selecting no rule only evaluates the configuration, to find the rules enabled for the file
+	enabled, _, err := l.evalWithTimeout(ctx, withRule(""), evalArgs, 0)
+	if err != nil {
+		return report.Report{}, false, err
+	}
+
+	merged := report.Report{
+		Aggregates:           make(map[string][]report.Aggregate),
+		IgnoreDirectives:     enabled.IgnoreDirectives,
+		IgnoreRanges:         enabled.IgnoreRanges,
+		UsedIgnoreDirectives: make(map[string]map[string][]string),
+		UsedIgnoreRanges:     make(map[string]map[string][]string),
+		EvaluatedRules:       map[string][]string{name: {}},
+	}
+
+	for _, rule := range enabled.EvaluatedRules[name] {
+		var ruleResult report.Report
+
+		// once the budget has run out, the remaining rules are skipped without being evaluated
+		timedOut := true
+
+		if remaining := time.Until(deadline); remaining > 0 {
+			if ruleResult, timedOut, err = l.evalWithTimeout(ctx, withRule(rule), evalArgs, remaining); err != nil {
+				return report.Report{}, false, err
+			}
+		}
+
+		if timedOut {
+			category, title, _ := strings.Cut(rule, "/")
+			merged.Notices = append(merged.Notices, report.Notice{
+				Title:    title,
+				Category: category,
+				Description: fmt.Sprintf(
+					"Rule skipped for %s, as evaluation exceeded the time budget of %s", name, l.ruleTimeout,
+				),
+				Level:    "notice",
+				Severity: "warning",
+			})
+
+			continue
+		}
+
+		merged.EvaluatedRules[name] = append(merged.EvaluatedRules[name], rule)
+		merged.Violations = append(merged.Violations, ruleResult.Violations...)
+		merged.Notices = append(merged.Notices, ruleResult.Notices...)
+		mergeUsedIgnoreDirectives(merged.UsedIgnoreDirectives, ruleResult.UsedIgnoreDirectives)
+		mergeUsedIgnoreDirectives(merged.UsedIgnoreRanges, ruleResult.UsedIgnoreRanges)
+
+		for key, aggregates := range ruleResult.Aggregates {
+			merged.Aggregates[key] = append(merged.Aggregates[key], aggregates...)
+		}
+	}
+
+	return merged, true, nil
+}
+
+// evalWithTimeout evaluates the lint query with the provided input, cancelling the evaluation if it
+// takes longer than timeout, unless it's 0. The returned bool is true if it was cancelled.
+func (l Linter) evalWithTimeout(
+	ctx context.Context,
+	inputValue ast.Value,
+	evalArgs []rego.EvalOption,
+	timeout time.Duration,
+) (report.Report, bool, error) {
+	evalArgs = append(slices.Clip(evalArgs), rego.EvalParsedInput(inputValue))
+
+	var cancel topdown.Cancel
+
+	if timeout > 0 {
+		cancel = topdown.NewCancel()
+		evalArgs = append(evalArgs, rego.EvalExternalCancel(cancel))
+
+		defer time.AfterFunc(timeout, cancel.Cancel).Stop()
+	} else {
+		evalArgs = append(evalArgs, exp.ExternalCancelNoOp)
+	}
+
+	resultSet, err := l.preparedQuery.Eval(ctx, evalArgs...)
+	if err != nil {
+		if cancel != nil && cancel.Cancelled() && topdown.IsCancel(err) {
+			return report.Report{}, true, nil
+		}
+
+		return report.Report{}, false, fmt.Errorf("error encountered in query evaluation %w", err)
+	}
+
+	result, err := report.FromResultSet(resultSet, false)
+	if err != nil {
+		return report.Report{}, false, fmt.Errorf("failed to convert result set to report: %w", err)
+	}
+
+	return result, false, nil
+}
+
+// ruleKeys returns the category/title of all built-in and custom rules, in a stable order.
+func (l Linter) ruleKeys() []string {
+	return slices.Compact(slices.Sorted(maps.Values(l.ruleFileNames)))
+}
+
+// customRuleKeys returns the category/title of all custom rules, in a stable order.
+func (l Linter) customRuleKeys() []string {
+	keys := make([]string, 0, len(l.customRuleModules))
+
+	add := func(module *ast.Module) {
+		if module == nil || module.Package == nil || !module.Package.Path.HasPrefix(customRulesRef) {
+			return
+		}
+
+		if key, ok := ruleKey(module.Package.Path); ok {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, b := range l.ruleBundles {
+		for _, mf := range b.Modules {
+			add(mf.Parsed)
+		}
+	}
+
+	for _, m := range l.customRuleModules {
+		add(m)
+	}
+
+	return slices.Compact(slices.Sorted(slices.Values(keys)))
+}
+
+// withAttribute returns a shallow copy of obj with the attribute key set to value.
+func withAttribute(obj ast.Object, key string, value *ast.Term) ast.Object {
+	keyTerm := ast.InternedTerm(key)
+	result := ast.NewObject()
+
+	obj.Foreach(func(k, v *ast.Term) {
+		if !k.Equal(keyTerm) {
+			result.Insert(k, v)
+		}
+	})
+
+	result.Insert(keyTerm, value)
+
+	return result
+}
@@ -0,0 +1,189 @@
+package linter
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+const unusedIgnoreDirectiveKey = "style/unused-ignore-directive"
+
+// unusedIgnoreDirectives returns a violation for each rule of an ignore directive, or block ignore
+// directive, that didn't suppress any violation, in files where the unused-ignore-directive rule was
+// evaluated, or in all files when reporting of unused ignores is enabled. Rules unknown to the linter
+// are always reported, while rules that weren't evaluated for the file, like rules disabled for the
+// file or aggregate rules when aggregates weren't evaluated, are not reported, as whether the directive
+// is still needed can't be determined.
+func (l Linter) unusedIgnoreDirectives(
+	ctx context.Context,
+	r report.Report,
+	aggregates map[string][]report.Aggregate,
+) ([]report.Violation, error) {
+	_, enabledAggregate, err := l.DetermineEnabledRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine enabled rules: %w", err)
+	}
+
+	keys := make(map[string]string)
+
+	for _, key := range l.ruleKeys() {
+		_, title, _ := strings.Cut(key, "/")
+		keys[title] = key
+	}
+
+	// a rule evaluated for a file may still not have been evaluated at all, if it's an
+	// aggregate rule and the aggregate rules weren't evaluated, like when linting a single file
+	evaluated := func(file, title string) bool {
+		if !slices.Contains(r.EvaluatedRules[file], keys[title]) {
+			return false
+		}
+
+		if slices.Contains(enabledAggregate, title) {
+			_, ok := aggregates[keys[title]]
+
+			return ok
+		}
+
+		return true
+	}
+
+	var violations []report.Violation
+
+	unused := func(file string, row int, title string, used []string, level string) {
+		switch {
+		case keys[title] == "":
+			violations = append(violations, unusedIgnoreDirective(file, row, level, "Ignore directive for unknown rule "+title))
+		case evaluated(file, title) && !slices.Contains(used, title):
+			violations = append(violations, unusedIgnoreDirective(file, row, level, "Ignore directive for "+title+" is unused"))
+		}
+	}
+
+	for _, file := range slices.Sorted(maps.Keys(r.IgnoreDirectives)) {
+		level, ok, err := l.unusedIgnoreDirectiveLevel(r, file)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		directives := r.IgnoreDirectives[file]
+
+		for _, key := range sortedRows(directives) {
+			row, _ := strconv.Atoi(key)
+
+			for _, title := range directives[key] {
+				// the row of a directive is that following the comment
+				unused(file, row-1, title, r.UsedIgnoreDirectives[file][key], level)
+			}
+		}
+	}
+
+	for _, file := range slices.Sorted(maps.Keys(r.IgnoreRanges)) {
+		level, ok, err := l.unusedIgnoreDirectiveLevel(r, file)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		for _, ignoreRange := range r.IgnoreRanges[file] {
+			for _, title := range ignoreRange.Rules {
+				unused(file, ignoreRange.Start, title, r.UsedIgnoreRanges[file][strconv.Itoa(ignoreRange.Start)], level)
+			}
+		}
+	}
+
+	return violations, nil
+}
+
+// unusedIgnoreDirectiveLevel returns the level to report unused ignore directives in the named file at,
+// and whether they are to be reported at all, which they are if the unused-ignore-directive rule was
+// evaluated for the file, or if reporting of unused ignores is enabled, in which case the level is
+// "error" unless the rule is enabled by configuration. The configured level includes any overrides.
+func (l Linter) unusedIgnoreDirectiveLevel(r report.Report, file string) (string, bool, error) {
+	if !l.reportUnusedIgnores && !slices.Contains(r.EvaluatedRules[file], unusedIgnoreDirectiveKey) {
+		return "", false, nil
+	}
+
+	if l.combinedCfg == nil {
+		return "error", true, nil
+	}
+
+	overrides, err := l.combinedCfg.MatchingOverrides(file, l.pathPrefix)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to match overrides for %s: %w", file, err)
+	}
+
+	category, title, _ := strings.Cut(unusedIgnoreDirectiveKey, "/")
+
+	if level := l.combinedCfg.WithOverridesApplied(overrides...).Rules[category][title].Level; level != "" &&
+		level != "ignore" {
+		return level, true, nil
+	}
+
+	return "error", true, nil
+}
+
+// evaluatedInAnyFile returns true if the rule identified by key, as category/title, was
+// evaluated for any of the files linted.
+func evaluatedInAnyFile(r report.Report, key string) bool {
+	for _, rules := range r.EvaluatedRules {
+		if slices.Contains(rules, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unusedIgnoreDirective(file string, row int, level, description string) report.Violation {
+	category, title, _ := strings.Cut(unusedIgnoreDirectiveKey, "/")
+
+	return report.Violation{
+		Title:       title,
+		Description: description,
+		Category:    category,
+		Level:       level,
+		RelatedResources: []report.RelatedResource{{
+			Description: "documentation",
+			Reference:   "https://www.openpolicyagent.org/projects/regal/rules/" + unusedIgnoreDirectiveKey,
+		}},
+		Location: report.Location{File: file, Row: row, Column: 1},
+	}
+}
+
+// sortedRows returns the rows of directives, sorted numerically.
+func sortedRows(directives map[string][]string) []string {
+	return slices.SortedFunc(maps.Keys(directives), func(a, b string) int {
+		x, _ := strconv.Atoi(a)
+		y, _ := strconv.Atoi(b)
+
+		return x - y
+	})
+}
+
+// mergeUsedIgnoreDirectives merges the rules of the used ignore directives in src into dst.
+func mergeUsedIgnoreDirectives(dst, src map[string]map[string][]string) {
+	for file, rows := range src {
+		if dst[file] == nil {
+			dst[file] = make(map[string][]string, len(rows))
+		}
+
+		for row, titles := range rows {
+			for _, title := range titles {
+				if !slices.Contains(dst[file][row], title) {
+					dst[file][row] = append(dst[file][row], title)
+				}
+			}
+		}
+	}
+}
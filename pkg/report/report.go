@@ -1,8 +1,14 @@
 package report
 
 import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"maps"
+	"path/filepath"
 	"slices"
+	"strings"
 
 	"github.com/open-policy-agent/opa/v1/rego"
 
@@ -39,8 +45,10 @@ type Violation struct {
 	Category         string            `json:"category"`
 	Level            string            `json:"level"`
 	RelatedResources []RelatedResource `json:"related_resources,omitempty"`
-	Location         Location          `json:"location"`
-	IsAggregate      bool              `json:"-"`
+	// Tags are the tags declared by the rule in its metadata, if any.
+	Tags        []string `json:"tags,omitempty"`
+	Location    Location `json:"location"`
+	IsAggregate bool     `json:"-"`
 }
 
 // Notice describes any notice found by Regal.
@@ -57,6 +65,18 @@ type Summary struct {
 	FilesFailed   int `json:"files_failed"`
 	RulesSkipped  int `json:"rules_skipped"`
 	NumViolations int `json:"num_violations"`
+	// BaselineMatched is the number of violations suppressed as they were found in the baseline.
+	BaselineMatched int `json:"baseline_matched,omitempty"`
+	// BaselineStale is the number of entries in the baseline that matched no violation in the report.
+	BaselineStale int `json:"baseline_stale,omitempty"`
+}
+
+// Threshold is the maximum number of warnings allowed in a category, or in total when
+// Category is empty, along with the number of warnings found.
+type Threshold struct {
+	Category    string `json:"category,omitempty"`
+	MaxWarnings int    `json:"max_warnings"`
+	Warnings    int    `json:"warnings"`
 }
 
 // Report aggregate of Violation as returned by a linter run.
@@ -67,22 +87,62 @@ type Report struct {
 	Metrics          map[string]any                 `json:"metrics,omitempty"`
 	AggregateProfile map[string]ProfileEntry        `json:"-"`
 	IgnoreDirectives map[string]map[string][]string `json:"ignore_directives,omitempty"`
-	Violations       []Violation                    `json:"violations"`
-	Notices          []Notice                       `json:"notices,omitempty"`
-	Profile          []ProfileEntry                 `json:"profile,omitempty"`
-	Summary          Summary                        `json:"summary"`
+	// IgnoreRanges are the block ignore directives found in each file, keyed by file name.
+	IgnoreRanges map[string][]IgnoreRange `json:"ignore_ranges,omitempty"`
+	// UsedIgnoreDirectives and UsedIgnoreRanges are the rules of the ignore directives, and of the block
+	// ignore directives, that suppressed a violation, keyed by file name and the row of the directive, as
+	// found in IgnoreDirectives and IgnoreRanges. These are used to find unused ignore directives, and are
+	// not part of the final report.
+	UsedIgnoreDirectives map[string]map[string][]string `json:"used_ignore_directives,omitempty"`
+	UsedIgnoreRanges     map[string]map[string][]string `json:"used_ignore_ranges,omitempty"`
+	// EvaluatedRules are the rules evaluated for each file, as category/title, keyed by file name.
+	// Like the used ignore directives, these are not part of the final report.
+	EvaluatedRules map[string][]string `json:"evaluated_rules,omitempty"`
+	Violations     []Violation         `json:"violations"`
+	Notices        []Notice            `json:"notices,omitempty"`
+	Profile        []ProfileEntry      `json:"profile,omitempty"`
+	// AggregateRuleProfile is the profiling information attributed to each rule, keyed by category/title,
+	// while being aggregated across files. Like AggregateProfile, this is not part of the final report.
+	AggregateRuleProfile map[string]RuleProfileEntry `json:"-"`
+	RuleProfile          []RuleProfileEntry          `json:"rule_profile,omitempty"`
+	CategoryProfile      []RuleProfileEntry          `json:"category_profile,omitempty"`
+	// Thresholds are the maximum number of warnings allowed, along with the number of warnings found.
+	Thresholds []Threshold `json:"thresholds,omitempty"`
+	Summary    Summary     `json:"summary"`
+}
+
+// IgnoreRange is a block ignore directive, i.e. the rules ignored from the row of
+// an ignore-start comment up until the row of the following ignore-end comment, or
+// the last row of the file if there is none.
+type IgnoreRange struct {
+	Rules []string `json:"rules"`
+	Start int      `json:"start"`
+	End   int      `json:"end"`
 }
 
 // ProfileEntry is a single entry of profiling information, keyed by location.
 // This data may have been aggregated across multiple runs.
 type ProfileEntry struct {
-	Location    string `json:"location"`
+	Location string `json:"location"`
+	// Rule is the category/title of the rule the location belongs to, if any.
+	Rule        string `json:"rule,omitempty"`
 	TotalTimeNs int64  `json:"total_time_ns"`
 	NumEval     int    `json:"num_eval"`
 	NumRedo     int    `json:"num_redo"`
 	NumGenExpr  int    `json:"num_gen_expr"`
 }
 
+// RuleProfileEntry is profiling information attributed to a single rule, or to all rules of a category
+// when Title is empty. This data has been aggregated across all files linted, including the evaluation
+// of aggregate rules.
+type RuleProfileEntry struct {
+	Category      string `json:"category"`
+	Title         string `json:"title,omitempty"`
+	TotalTimeNs   int64  `json:"total_time_ns"`
+	NumEval       int    `json:"num_eval"`
+	NumViolations int    `json:"num_violations"`
+}
+
 // An Aggregate is data collected by some rule while processing a file AST, to be used later by other rules needing a
 // global context (i.e. broader than per-file)
 // Rule authors are expected to collect the minimum needed data, to avoid performance problems
@@ -182,6 +242,77 @@ func (r *Report) AggregateProfileToSortedProfile(numResults int) {
 	r.Profile = r.Profile[:numResults]
 }
 
+// AddRuleProfileEntries adds the rule and category profile entries to the aggregated rule profile
+// of the report, summing the time, evaluations and violations of entries with the same key.
+func (r *Report) AddRuleProfileEntries(prof map[string]RuleProfileEntry) {
+	if r.AggregateRuleProfile == nil {
+		r.AggregateRuleProfile = make(map[string]RuleProfileEntry, len(prof))
+	}
+
+	for key, entry := range prof {
+		if existing, ok := r.AggregateRuleProfile[key]; ok {
+			entry.TotalTimeNs += existing.TotalTimeNs
+			entry.NumEval += existing.NumEval
+			entry.NumViolations += existing.NumViolations
+		}
+
+		r.AggregateRuleProfile[key] = entry
+	}
+}
+
+// AggregateRuleProfileToSortedProfile counts the violations of each rule, and sets the rule
+// and category profiles of the report, sorted by the total time spent evaluating each.
+func (r *Report) AggregateRuleProfileToSortedProfile() {
+	rules := maps.Clone(r.AggregateRuleProfile)
+	if rules == nil {
+		rules = make(map[string]RuleProfileEntry)
+	}
+
+	for i := range r.Violations {
+		key := r.Violations[i].Category + "/" + r.Violations[i].Title
+		entry := rules[key]
+		entry.Category, entry.Title = r.Violations[i].Category, r.Violations[i].Title
+		entry.NumViolations++
+		rules[key] = entry
+	}
+
+	categories := make(map[string]RuleProfileEntry)
+
+	r.RuleProfile = make([]RuleProfileEntry, 0, len(rules))
+	for _, entry := range rules {
+		r.RuleProfile = append(r.RuleProfile, entry)
+
+		category := categories[entry.Category]
+		category.Category = entry.Category
+		category.TotalTimeNs += entry.TotalTimeNs
+		category.NumEval += entry.NumEval
+		category.NumViolations += entry.NumViolations
+		categories[entry.Category] = category
+	}
+
+	r.CategoryProfile = slices.Collect(maps.Values(categories))
+
+	sortRuleProfile(r.RuleProfile)
+	sortRuleProfile(r.CategoryProfile)
+}
+
+func sortRuleProfile(entries []RuleProfileEntry) {
+	slices.SortFunc(entries, func(a, b RuleProfileEntry) int {
+		return cmp.Or(
+			cmp.Compare(b.TotalTimeNs, a.TotalTimeNs),
+			strings.Compare(a.Category, b.Category),
+			strings.Compare(a.Title, b.Title),
+		)
+	})
+}
+
+// RecountViolations updates the violation counts of the summary to reflect the current
+// set of violations, like after violations have been filtered from the report.
+func (r *Report) RecountViolations() {
+	r.Summary.NumViolations = len(r.Violations)
+	r.Summary.FilesFailed = len(r.ViolationsFileCount())
+}
+
 // ViolationsFileCount returns the number of files containing violations.
 func (r *Report) ViolationsFileCount() map[string]int {
 	fc := map[string]int{}
@@ -192,6 +323,27 @@ func (r *Report) ViolationsFileCount() map[string]int {
 	return fc
 }
 
+// Exceeded returns true if more warnings than allowed by the threshold were found.
+func (t Threshold) Exceeded() bool {
+	return t.Warnings > t.MaxWarnings
+}
+
+// Fingerprint returns a stable identifier for the violation, based on the rule, the file and
+// the text at the location of the violation, with whitespace normalized. As opposed to the row
+// and column, this doesn't change when code is moved around in the file.
+func (v Violation) Fingerprint() string {
+	var text string
+	if v.Location.Text != nil {
+		text = strings.Join(strings.Fields(*v.Location.Text), " ")
+	}
+
+	sum := sha256.Sum256([]byte(
+		v.Category + "/" + v.Title + "\x00" + filepath.ToSlash(v.Location.File) + "\x00" + text,
+	))
+
+	return hex.EncodeToString(sum[:16])
+}
+
 // String shorthand form for a Location.
 func (l Location) String() string {
 	if l.Row == 0 && l.Column == 0 {
@@ -0,0 +1,227 @@
+package reporter
+
+import (
+	"cmp"
+	"context"
+	"html/template"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/open-policy-agent/regal/internal/embeds"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+var htmlTemplate = template.Must(template.ParseFS(embeds.EmbedTemplatesFS, "templates/report/report.html.tpl"))
+
+// HTMLReporter reports violations as a single, self-contained HTML page, grouping violations
+// by file, category and rule, and including summary charts and the profile, when available.
+type HTMLReporter struct {
+	out io.Writer
+}
+
+type htmlReport struct {
+	Summary     report.Summary
+	Charts      []htmlChart
+	Files       []htmlFile
+	RuleProfile []htmlProfileRow
+}
+
+type htmlChart struct {
+	Title string
+	Bars  []htmlBar
+}
+
+type htmlBar struct {
+	Label   string
+	Count   int
+	Percent int
+}
+
+type htmlFile struct {
+	Name       string
+	Count      int
+	Categories []htmlCategory
+}
+
+type htmlCategory struct {
+	Name  string
+	Rules []htmlRule
+}
+
+type htmlRule struct {
+	Title            string
+	Description      string
+	RelatedResources []report.RelatedResource
+	Violations       []htmlViolation
+}
+
+type htmlViolation struct {
+	Level    string
+	Location string
+	Excerpt  *htmlExcerpt
+}
+
+// htmlExcerpt is the line of a violation, split into the text before, at, and after its location.
+type htmlExcerpt struct {
+	Row       int
+	Before    string
+	Highlight string
+	After     string
+}
+
+type htmlProfileRow struct {
+	Name          string
+	Time          string
+	NumEval       int
+	NumViolations int
+}
+
+// NewHTMLReporter creates a new HTMLReporter.
+func NewHTMLReporter(out io.Writer) HTMLReporter {
+	return HTMLReporter{out: out}
+}
+
+// Publish prints an HTML report to the configured output.
+func (tr HTMLReporter) Publish(_ context.Context, r report.Report) error {
+	return htmlTemplate.Execute(tr.out, htmlReport{
+		Summary:     r.Summary,
+		Charts:      htmlCharts(r.Violations),
+		Files:       htmlFiles(r.Violations),
+		RuleProfile: htmlRuleProfile(r.RuleProfile, r.CategoryProfile),
+	})
+}
+
+func htmlFiles(violations []report.Violation) []htmlFile {
+	// file -> category -> title -> violations
+	grouped := make(map[string]map[string]map[string][]report.Violation)
+
+	for _, violation := range violations { //nolint:gocritic
+		categories, ok := grouped[violation.Location.File]
+		if !ok {
+			categories = make(map[string]map[string][]report.Violation)
+			grouped[violation.Location.File] = categories
+		}
+
+		if _, ok := categories[violation.Category]; !ok {
+			categories[violation.Category] = make(map[string][]report.Violation)
+		}
+
+		categories[violation.Category][violation.Title] = append(
+			categories[violation.Category][violation.Title], violation,
+		)
+	}
+
+	files := make([]htmlFile, 0, len(grouped))
+
+	for _, file := range slices.Sorted(maps.Keys(grouped)) {
+		hf := htmlFile{Name: file}
+
+		for _, category := range slices.Sorted(maps.Keys(grouped[file])) {
+			hc := htmlCategory{Name: category}
+
+			for _, title := range slices.Sorted(maps.Keys(grouped[file][category])) {
+				vs := grouped[file][category][title]
+				slices.SortStableFunc(vs, func(a, b report.Violation) int {
+					return cmp.Or(cmp.Compare(a.Location.Row, b.Location.Row), cmp.Compare(a.Location.Column, b.Location.Column))
+				})
+
+				hr := htmlRule{Title: title, Description: vs[0].Description, RelatedResources: vs[0].RelatedResources}
+
+				for _, violation := range vs { //nolint:gocritic
+					hr.Violations = append(hr.Violations, htmlViolation{
+						Level:    violation.Level,
+						Location: violation.Location.String(),
+						Excerpt:  htmlExcerptOf(violation.Location),
+					})
+				}
+
+				hf.Count += len(vs)
+				hc.Rules = append(hc.Rules, hr)
+			}
+
+			hf.Categories = append(hf.Categories, hc)
+		}
+
+		files = append(files, hf)
+	}
+
+	return files
+}
+
+// htmlExcerptOf splits the text of the location into the part before the location, the part
+// to highlight, and the part after it. The highlight ends at the end position if it is on the
+// same line, or else at the end of the line.
+func htmlExcerptOf(location report.Location) *htmlExcerpt {
+	if location.Text == nil || location.Row == 0 {
+		return nil
+	}
+
+	text := *location.Text
+	start := min(max(location.Column-1, 0), len(text))
+
+	end := len(text)
+	if location.End != nil && location.End.Row == location.Row {
+		end = min(max(location.End.Column-1, start), len(text))
+	}
+
+	return &htmlExcerpt{Row: location.Row, Before: text[:start], Highlight: text[start:end], After: text[end:]}
+}
+
+func htmlCharts(violations []report.Violation) []htmlChart {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	byCategory := make(map[string]int)
+	byLevel := make(map[string]int)
+
+	for i := range violations {
+		byCategory[violations[i].Category]++
+		byLevel[violations[i].Level]++
+	}
+
+	return []htmlChart{
+		{Title: "Violations by category", Bars: htmlBars(byCategory, len(violations))},
+		{Title: "Violations by level", Bars: htmlBars(byLevel, len(violations))},
+	}
+}
+
+// htmlBars returns a bar for each label, sorted by count and then label, with the width
+// of each bar being the percentage of the total.
+func htmlBars(counts map[string]int, total int) []htmlBar {
+	bars := make([]htmlBar, 0, len(counts))
+	for label, count := range counts {
+		bars = append(bars, htmlBar{Label: label, Count: count, Percent: count * 100 / total})
+	}
+
+	slices.SortFunc(bars, func(a, b htmlBar) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Label, b.Label))
+	})
+
+	return bars
+}
+
+func htmlRuleProfile(rules, categories []report.RuleProfileEntry) []htmlProfileRow {
+	rows := make([]htmlProfileRow, 0, len(rules)+len(categories))
+
+	for _, entry := range rules {
+		rows = append(rows, htmlProfileRowOf(entry.Category+"/"+entry.Title, entry))
+	}
+
+	for _, entry := range categories {
+		rows = append(rows, htmlProfileRowOf(entry.Category+" (category)", entry))
+	}
+
+	return rows
+}
+
+func htmlProfileRowOf(name string, entry report.RuleProfileEntry) htmlProfileRow {
+	return htmlProfileRow{
+		Name:          name,
+		Time:          time.Duration(entry.TotalTimeNs).Round(time.Microsecond).String(),
+		NumEval:       entry.NumEval,
+		NumViolations: entry.NumViolations,
+	}
+}
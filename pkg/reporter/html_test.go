@@ -0,0 +1,81 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+func TestHTMLReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	r := rep
+	r.RuleProfile = []report.RuleProfileEntry{
+		{Category: "legal", Title: "breaking-the-law", TotalTimeNs: 1500000, NumEval: 12, NumViolations: 1},
+	}
+	r.CategoryProfile = []report.RuleProfileEntry{{Category: "legal", TotalTimeNs: 1500000, NumEval: 12, NumViolations: 1}}
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewHTMLReporter(&buf).Publish(t.Context(), r))(t)
+
+	if expect := testutil.MustReadFile(t, "testdata/html/reporter.html"); buf.String() != expect {
+		t.Errorf("expected %s, got %s", expect, buf.String())
+	}
+}
+
+func TestHTMLReporterPublishNoViolations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewHTMLReporter(&buf).Publish(t.Context(), report.Report{}))(t)
+
+	if !strings.Contains(buf.String(), "<p>No violations found.</p>") {
+		t.Errorf("expected no violations message, got %s", buf.String())
+	}
+
+	if strings.Contains(buf.String(), "<h2>Profile</h2>") {
+		t.Errorf("expected no profile without profiling, got %s", buf.String())
+	}
+}
+
+func TestHTMLExcerptOf(t *testing.T) {
+	t.Parallel()
+
+	text := util.Pointer("allow = <b>")
+
+	cases := map[string]struct {
+		location report.Location
+		expected *htmlExcerpt
+	}{
+		"with end": {
+			location: report.Location{Row: 3, Column: 7, End: &report.Position{Row: 3, Column: 8}, Text: text},
+			expected: &htmlExcerpt{Row: 3, Before: "allow ", Highlight: "=", After: " <b>"},
+		},
+		"end on other line": {
+			location: report.Location{Row: 3, Column: 7, End: &report.Position{Row: 5, Column: 2}, Text: text},
+			expected: &htmlExcerpt{Row: 3, Before: "allow ", Highlight: "= <b>"},
+		},
+		"column out of range": {
+			location: report.Location{Row: 3, Column: 40, Text: text},
+			expected: &htmlExcerpt{Row: 3, Before: "allow = <b>"},
+		},
+		"no text": {
+			location: report.Location{Row: 3, Column: 7},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := htmlExcerptOf(tc.location)
+			if (got == nil) != (tc.expected == nil) || got != nil && *got != *tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
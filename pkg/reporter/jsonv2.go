@@ -0,0 +1,197 @@
+package reporter
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/fixer"
+	"github.com/open-policy-agent/regal/pkg/fixer/fixes"
+	"github.com/open-policy-agent/regal/pkg/report"
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
+)
+
+// JSONV2SchemaVersion is the version of the JSON schema used by the JSONV2Reporter. The schema
+// is embedded in the binary, at schemas/regal/report/v2.json.
+const JSONV2SchemaVersion = 2
+
+// JSONV2Reporter reports violations as JSON, following a versioned schema. As opposed to the
+// JSONReporter, each violation includes both its start and end position, a stable fingerprint,
+// whether it's fixable, and the edits suggested to fix it, when a fix exists for the rule.
+type JSONV2Reporter struct {
+	out      io.Writer
+	readFile func(string) ([]byte, error)
+}
+
+type jsonV2Report struct {
+	Metrics         map[string]any            `json:"metrics,omitempty"`
+	Violations      []jsonV2Violation         `json:"violations"`
+	Notices         []report.Notice           `json:"notices,omitempty"`
+	Profile         []report.ProfileEntry     `json:"profile,omitempty"`
+	RuleProfile     []report.RuleProfileEntry `json:"rule_profile,omitempty"`
+	CategoryProfile []report.RuleProfileEntry `json:"category_profile,omitempty"`
+	Thresholds      []report.Threshold        `json:"thresholds,omitempty"`
+	Summary         report.Summary            `json:"summary"`
+	SchemaVersion   int                       `json:"schema_version"`
+}
+
+type jsonV2Violation struct {
+	Title            string                   `json:"title"`
+	Description      string                   `json:"description"`
+	Category         string                   `json:"category"`
+	Level            string                   `json:"level"`
+	RelatedResources []report.RelatedResource `json:"related_resources,omitempty"`
+	Tags             []string                 `json:"tags,omitempty"`
+	Location         jsonV2Location           `json:"location"`
+	Fingerprint      string                   `json:"fingerprint"`
+	Fixable          bool                     `json:"fixable"`
+	SuggestedEdits   []jsonV2Edit             `json:"suggested_edits,omitempty"`
+}
+
+type jsonV2Location struct {
+	File  string          `json:"file"`
+	Start report.Position `json:"start"`
+	End   report.Position `json:"end"`
+	Text  *string         `json:"text,omitempty"`
+}
+
+// jsonV2Edit replaces the text from Start up until, but not including, End.
+type jsonV2Edit struct {
+	Start report.Position `json:"start"`
+	End   report.Position `json:"end"`
+	Text  string          `json:"text"`
+}
+
+// NewJSONV2Reporter creates a new JSONV2Reporter.
+func NewJSONV2Reporter(out io.Writer) JSONV2Reporter {
+	return JSONV2Reporter{out: out, readFile: os.ReadFile}
+}
+
+// Publish prints a JSON report following the v2 schema to the configured output.
+func (tr JSONV2Reporter) Publish(_ context.Context, r report.Report) error {
+	f := fixer.NewFixer().RegisterFixes(fixes.NewDefaultFixes()...)
+	contents := make(map[string]*string)
+
+	violations := make([]jsonV2Violation, 0, len(r.Violations))
+
+	for i := range r.Violations {
+		v := r.Violations[i]
+
+		fix, fixable := f.GetFixForName(v.Title)
+
+		var edits []jsonV2Edit
+		if fixable {
+			edits = tr.suggestedEdits(fix, v, contents)
+		}
+
+		violations = append(violations, jsonV2Violation{
+			Title:            v.Title,
+			Description:      v.Description,
+			Category:         v.Category,
+			Level:            v.Level,
+			RelatedResources: v.RelatedResources,
+			Tags:             v.Tags,
+			Location:         jsonV2LocationFrom(v.Location),
+			Fingerprint:      v.Fingerprint(),
+			Fixable:          fixable,
+			SuggestedEdits:   edits,
+		})
+	}
+
+	enc := encoding.JSON().NewEncoder(tr.out)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(jsonV2Report{
+		Metrics:         r.Metrics,
+		Violations:      violations,
+		Notices:         r.Notices,
+		Profile:         r.Profile,
+		RuleProfile:     r.RuleProfile,
+		CategoryProfile: r.CategoryProfile,
+		Thresholds:      r.Thresholds,
+		Summary:         r.Summary,
+		SchemaVersion:   JSONV2SchemaVersion,
+	})
+}
+
+// suggestedEdits applies the fix to the file of the violation, and returns the edits needed to
+// turn the original contents into the fixed ones. Files that can't be read, and fixes that fail
+// or only rename the file, result in no edits. File contents are cached in contents by name.
+func (tr JSONV2Reporter) suggestedEdits(fix fixes.Fix, v report.Violation, contents map[string]*string) []jsonV2Edit {
+	content, ok := contents[v.Location.File]
+	if !ok {
+		if bs, err := tr.readFile(v.Location.File); err == nil {
+			content = util.Pointer(string(bs))
+		}
+
+		contents[v.Location.File] = content
+	}
+
+	if content == nil {
+		return nil
+	}
+
+	results, err := fix.Fix(
+		&fixes.FixCandidate{Filename: v.Location.File, Contents: *content},
+		&fixes.RuntimeOptions{Locations: []report.Location{v.Location}},
+	)
+	if err != nil || len(results) == 0 || results[0].Rename != nil || results[0].Contents == *content {
+		return nil
+	}
+
+	return []jsonV2Edit{editBetween(*content, results[0].Contents)}
+}
+
+func jsonV2LocationFrom(l report.Location) jsonV2Location {
+	start := report.Position{Row: l.Row, Column: l.Column}
+
+	end := start
+	if l.End != nil {
+		end = *l.End
+	}
+
+	return jsonV2Location{File: l.File, Start: start, End: end, Text: l.Text}
+}
+
+// editBetween returns a single edit replacing the part of before that differs from after,
+// i.e. everything between their common prefix and their common suffix.
+func editBetween(before, after string) jsonV2Edit {
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+
+	// don't split multi-byte characters
+	for prefix > 0 && prefix < len(before) && !utf8.RuneStart(before[prefix]) {
+		prefix--
+	}
+
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+
+	for suffix > 0 && !utf8.RuneStart(before[len(before)-suffix]) {
+		suffix--
+	}
+
+	return jsonV2Edit{
+		Start: positionAt(before, prefix),
+		End:   positionAt(before, len(before)-suffix),
+		Text:  after[prefix : len(after)-suffix],
+	}
+}
+
+// positionAt returns the row and column of the byte at offset in text, both starting at 1.
+func positionAt(text string, offset int) report.Position {
+	preceding := text[:offset]
+
+	return report.Position{
+		Row:    strings.Count(preceding, "\n") + 1,
+		Column: offset - strings.LastIndex(preceding, "\n"),
+	}
+}
@@ -0,0 +1,148 @@
+package reporter
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+
+	"github.com/open-policy-agent/regal/internal/embeds"
+	"github.com/open-policy-agent/regal/internal/testutil"
+	"github.com/open-policy-agent/regal/pkg/report"
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
+)
+
+func TestJSONV2ReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewJSONV2Reporter(&buf).Publish(t.Context(), rep))(t)
+
+	if expect := testutil.MustReadFile(t, "testdata/json-v2/reporter.json"); expect != buf.String() {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+}
+
+func TestJSONV2ReporterPublishNoViolations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewJSONV2Reporter(&buf).Publish(t.Context(), report.Report{}))(t)
+
+	if expect := testutil.MustReadFile(t, "testdata/json-v2/reporter-no-violations.json"); expect != buf.String() {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+}
+
+func TestJSONV2ReporterSuggestedEdits(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{"p.rego": "package p\n\nx = 1\n\ny = \"ä\"\n"}
+	tr := JSONV2Reporter{readFile: func(name string) ([]byte, error) {
+		if contents, ok := files[name]; ok {
+			return []byte(contents), nil
+		}
+
+		return nil, os.ErrNotExist
+	}}
+
+	violation := func(title string, row, col int) report.Violation {
+		return report.Violation{Title: title, Location: report.Location{File: "p.rego", Row: row, Column: col}}
+	}
+
+	var buf bytes.Buffer
+
+	tr.out = &buf
+
+	testutil.NoErr(tr.Publish(t.Context(), report.Report{Violations: []report.Violation{
+		violation("use-assignment-operator", 3, 3),
+		violation("use-assignment-operator", 5, 3),
+		violation("use-assignment-operator", 4, 1), // no = at location
+		violation("prefer-snake-case", 3, 1),       // not fixable
+	}}))(t)
+
+	got := testutil.Must(encoding.JSONUnmarshalTo[jsonV2Report](buf.Bytes()))(t)
+
+	expected := [][]jsonV2Edit{
+		{{Start: report.Position{Row: 3, Column: 3}, End: report.Position{Row: 3, Column: 3}, Text: ":"}},
+		{{Start: report.Position{Row: 5, Column: 3}, End: report.Position{Row: 5, Column: 3}, Text: ":"}},
+		nil,
+		nil,
+	}
+
+	for i, v := range got.Violations {
+		if diff := cmp.Diff(expected[i], v.SuggestedEdits); diff != "" {
+			t.Errorf("unexpected edits for violation %d (-want, +got):\n%s", i, diff)
+		}
+
+		if fixable := i < 3; v.Fixable != fixable {
+			t.Errorf("expected violation %d to have fixable %v", i, fixable)
+		}
+	}
+}
+
+func TestJSONV2ReporterMatchesSchema(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewJSONV2Reporter(&buf).Publish(t.Context(), rep))(t)
+
+	schema := testutil.Must(embeds.SchemasFS.ReadFile("schemas/regal/report/v2.json"))(t)
+
+	rs := testutil.Must(rego.New(
+		rego.Query("json.match_schema(input.document, input.schema)"),
+		rego.Input(map[string]any{
+			"document": testutil.Must(encoding.JSONUnmarshalTo[any](buf.Bytes()))(t),
+			"schema":   testutil.Must(encoding.JSONUnmarshalTo[any](schema))(t),
+		}),
+		rego.SetRegoVersion(ast.RegoV1),
+	).Eval(t.Context()))(t)
+
+	result, ok := rs[0].Expressions[0].Value.([]any)
+	if !ok || result[0] != true {
+		t.Errorf("expected report to match schema, got %v", rs[0].Expressions[0].Value)
+	}
+}
+
+func TestEditBetween(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		before, after string
+		expected      jsonV2Edit
+	}{
+		"insert": {
+			before: "a\nb = 1\n",
+			after:  "a\nb := 1\n",
+			expected: jsonV2Edit{
+				Start: report.Position{Row: 2, Column: 3}, End: report.Position{Row: 2, Column: 3}, Text: ":",
+			},
+		},
+		"delete lines": {
+			before:   "a\n\n\n\nb\n",
+			after:    "a\n\nb\n",
+			expected: jsonV2Edit{Start: report.Position{Row: 3, Column: 1}, End: report.Position{Row: 5, Column: 1}},
+		},
+		"multi-byte": {
+			before: "x := \"ä\"\n",
+			after:  "x := \"ö\"\n",
+			expected: jsonV2Edit{
+				Start: report.Position{Row: 1, Column: 7}, End: report.Position{Row: 1, Column: 9}, Text: "ö",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.expected, editBetween(tc.before, tc.after)); diff != "" {
+				t.Errorf("unexpected edit (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package reporter
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// markdownLevels are the violation levels, in the order they're shown in the summary table.
+var markdownLevels = []string{"error", "warning", "info", "hint"}
+
+// MarkdownReporter reports violations as Markdown, suitable for pull request comments and
+// job summaries. The report starts with the number of violations per category and level,
+// followed by a collapsible table of violations for each file.
+type MarkdownReporter struct {
+	out       io.Writer
+	sizeLimit int
+}
+
+// NewMarkdownReporter creates a new MarkdownReporter.
+func NewMarkdownReporter(out io.Writer) MarkdownReporter {
+	return MarkdownReporter{out: out}
+}
+
+// WithSizeLimit sets the maximum size of the report in bytes, where 0 means no limit. When the
+// report would exceed the limit, the violations that don't fit are left out, and a footer with
+// the number of violations not shown is added instead. If not even the heading and the counts
+// table fit, only the summary line and the footer are reported, which may still exceed limits
+// too small to hold them.
+func (tr MarkdownReporter) WithSizeLimit(sizeLimit int) MarkdownReporter {
+	tr.sizeLimit = sizeLimit
+
+	return tr
+}
+
+// Publish prints a Markdown report to the configured output.
+func (tr MarkdownReporter) Publish(_ context.Context, r report.Report) error {
+	sb := &strings.Builder{}
+	sb.WriteString("### Regal Lint Report\n\n")
+
+	if len(r.Violations) == 0 {
+		fmt.Fprintf(sb, "%d %s linted. No violations found.\n",
+			r.Summary.FilesScanned, pluralize("file", r.Summary.FilesScanned))
+
+		_, err := io.WriteString(tr.out, sb.String())
+
+		return err
+	}
+
+	summary := fmt.Sprintf("%d %s linted. %d %s found in %d %s.\n",
+		r.Summary.FilesScanned, pluralize("file", r.Summary.FilesScanned),
+		len(r.Violations), pluralize("violation", len(r.Violations)),
+		r.Summary.FilesFailed, pluralize("file", r.Summary.FilesFailed),
+	)
+
+	counts := &strings.Builder{}
+	writeMarkdownCounts(counts, r.Violations)
+
+	omitted := len(r.Violations)
+
+	// when not even the heading and the counts fit, fall back to the summary line and the footer
+	if tr.sizeLimit > 0 && sb.Len()+len(summary)+1+counts.Len()+markdownFooterSize(len(r.Violations)) > tr.sizeLimit {
+		sb.Reset()
+		sb.WriteString(summary)
+	} else {
+		sb.WriteString(summary + "\n" + counts.String())
+
+		omitted = tr.writeMarkdownFiles(sb, r.Violations)
+	}
+
+	if omitted > 0 {
+		fmt.Fprintf(sb, "\n_%d more %s not shown._\n", omitted, pluralize("violation", omitted))
+	}
+
+	_, err := io.WriteString(tr.out, sb.String())
+
+	return err
+}
+
+// markdownFooterSize is the room kept for the footer telling how many violations were left out.
+func markdownFooterSize(numViolations int) int {
+	return len("\n_ more violations not shown._\n") + len(strconv.Itoa(numViolations))
+}
+
+// writeMarkdownCounts writes a table with the number of violations per category and level,
+// with a column for each level found.
+func writeMarkdownCounts(sb *strings.Builder, violations []report.Violation) {
+	counts := make(map[string]map[string]int)
+	found := make(map[string]bool)
+
+	for i := range violations {
+		if counts[violations[i].Category] == nil {
+			counts[violations[i].Category] = make(map[string]int)
+		}
+
+		counts[violations[i].Category][violations[i].Level]++
+		found[violations[i].Level] = true
+	}
+
+	levels := slices.DeleteFunc(slices.Clone(markdownLevels), func(level string) bool { return !found[level] })
+
+	sb.WriteString("| Category |")
+
+	for _, level := range levels {
+		sb.WriteString(" " + strings.ToUpper(level[:1]) + level[1:] + " |")
+	}
+
+	sb.WriteString(" Total |\n| --- |" + strings.Repeat(" ---: |", len(levels)+1) + "\n")
+
+	for _, category := range slices.Sorted(maps.Keys(counts)) {
+		total := 0
+
+		sb.WriteString("| " + markdownEscape(category) + " |")
+
+		for _, level := range levels {
+			total += counts[category][level]
+			sb.WriteString(" " + strconv.Itoa(counts[category][level]) + " |")
+		}
+
+		sb.WriteString(" " + strconv.Itoa(total) + " |\n")
+	}
+}
+
+// writeMarkdownFiles writes a collapsible table of violations for each file, and returns the
+// number of violations left out due to the size limit.
+func (tr MarkdownReporter) writeMarkdownFiles(sb *strings.Builder, violations []report.Violation) int {
+	perFile := make(map[string][]report.Violation)
+	for i := range violations {
+		perFile[violations[i].Location.File] = append(perFile[violations[i].Location.File], violations[i])
+	}
+
+	footerSize := markdownFooterSize(len(violations))
+	sectionEnd := "\n</details>\n"
+	written := 0
+
+	for _, file := range slices.Sorted(maps.Keys(perFile)) {
+		vs := perFile[file]
+		slices.SortStableFunc(vs, func(a, b report.Violation) int {
+			return cmp.Or(cmp.Compare(a.Location.Row, b.Location.Row), cmp.Compare(a.Location.Column, b.Location.Column))
+		})
+
+		sectionStart := fmt.Sprintf(
+			"\n<details>\n<summary><code>%s</code> (%d %s)</summary>\n\n"+
+				"| Location | Level | Rule | Description |\n| --- | --- | --- | --- |\n",
+			markdownEscape(file), len(vs), pluralize("violation", len(vs)),
+		)
+
+		for i := range vs {
+			row := markdownRow(vs[i])
+
+			needed := len(row) + len(sectionEnd)
+			if i == 0 {
+				needed += len(sectionStart)
+			}
+
+			if tr.sizeLimit > 0 && sb.Len()+needed+footerSize > tr.sizeLimit {
+				if i > 0 {
+					sb.WriteString(sectionEnd)
+				}
+
+				return len(violations) - written
+			}
+
+			if i == 0 {
+				sb.WriteString(sectionStart)
+			}
+
+			sb.WriteString(row)
+
+			written++
+		}
+
+		sb.WriteString(sectionEnd)
+	}
+
+	return 0
+}
+
+func markdownRow(violation report.Violation) string {
+	rule := violation.Category + "/" + violation.Title
+	if url := getDocumentationURL(violation); url != "" {
+		rule = "[" + rule + "](" + url + ")"
+	}
+
+	return fmt.Sprintf("| %d:%d | %s | %s | %s |\n",
+		violation.Location.Row, violation.Location.Column,
+		violation.Level, markdownEscape(rule), markdownEscape(violation.Description),
+	)
+}
+
+// markdownEscape makes text safe for use in a table cell, and in inline HTML.
+func markdownEscape(text string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ", "<", "&lt;", ">", "&gt;").Replace(strings.TrimSpace(text))
+}
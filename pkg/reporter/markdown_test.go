@@ -0,0 +1,75 @@
+package reporter
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+func TestMarkdownReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewMarkdownReporter(&buf).Publish(t.Context(), rep))(t)
+
+	if expect := testutil.MustReadFile(t, "testdata/markdown/reporter.md"); buf.String() != expect {
+		t.Errorf("expected %s, got %s", expect, buf.String())
+	}
+}
+
+func TestMarkdownReporterPublishNoViolations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewMarkdownReporter(&buf).Publish(t.Context(), report.Report{}))(t)
+
+	if expect := "### Regal Lint Report\n\n0 files linted. No violations found.\n"; buf.String() != expect {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+}
+
+func TestMarkdownReporterPublishSizeLimit(t *testing.T) {
+	t.Parallel()
+
+	violations := make([]report.Violation, 0, 100)
+	for i := range 100 {
+		violations = append(violations, report.Violation{
+			Title:       "some-rule",
+			Description: "Some description",
+			Category:    "style",
+			Level:       "warning",
+			Location:    report.Location{File: "p.rego", Row: i + 1, Column: 1},
+		})
+	}
+
+	r := report.Report{Violations: violations, Summary: report.Summary{FilesScanned: 1, FilesFailed: 1}}
+
+	for _, limit := range []int{1000, 2000} {
+		var buf bytes.Buffer
+		testutil.NoErr(NewMarkdownReporter(&buf).WithSizeLimit(limit).Publish(t.Context(), r))(t)
+
+		if buf.Len() > limit {
+			t.Errorf("expected report to be at most %d bytes, got %d", limit, buf.Len())
+		}
+
+		shown := strings.Count(buf.String(), "| warning |")
+		if !strings.HasSuffix(buf.String(), "</details>\n\n_"+strconv.Itoa(100-shown)+" more violations not shown._\n") {
+			t.Errorf("expected footer with the %d violations not shown, got %s", 100-shown, buf.String())
+		}
+	}
+}
+
+func TestMarkdownReporterPublishSizeLimitTooSmallForCounts(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewMarkdownReporter(&buf).WithSizeLimit(100).Publish(t.Context(), rep))(t)
+
+	if expect := "3 files linted. 2 violations found in 2 files.\n\n_2 more violations not shown._\n"; buf.String() != expect {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+}
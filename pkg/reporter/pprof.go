@@ -0,0 +1,93 @@
+package reporter
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// WriteProfile writes the full profile collected while linting with profiling enabled, i.e.
+// the AggregateProfile of the report, in the gzipped protobuf format read by `go tool pprof`.
+// Each sample is an expression in a Rego module, with the time spent evaluating it and the
+// number of evaluations as values. Expressions belonging to a rule are attributed to a frame
+// named after the rule, on top of a frame named after the category of the rule, while other
+// expressions are attributed to a frame named after the file they're in.
+func WriteProfile(w io.Writer, r report.Report) error {
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "time", Unit: "nanoseconds"},
+			{Type: "evals", Unit: "count"},
+		},
+		DefaultSampleType: "time",
+		PeriodType:        &profile.ValueType{Type: "time", Unit: "nanoseconds"},
+		Period:            1,
+	}
+
+	functions := make(map[string]*profile.Function)
+	locations := make(map[string]*profile.Location)
+
+	location := func(name, file string, line int64) *profile.Location {
+		fn, ok := functions[name+"\x00"+file]
+		if !ok {
+			fn = &profile.Function{ID: uint64(len(p.Function) + 1), Name: name, SystemName: name, Filename: file}
+			functions[name+"\x00"+file] = fn
+			p.Function = append(p.Function, fn)
+		}
+
+		key := name + "\x00" + file + "\x00" + strconv.FormatInt(line, 10)
+
+		loc, ok := locations[key]
+		if !ok {
+			loc = &profile.Location{ID: uint64(len(p.Location) + 1), Line: []profile.Line{{Function: fn, Line: line}}}
+			locations[key] = loc
+			p.Location = append(p.Location, loc)
+		}
+
+		return loc
+	}
+
+	// sorted for a deterministic output
+	entries := slices.SortedFunc(maps.Values(r.AggregateProfile), func(a, b report.ProfileEntry) int {
+		return cmp.Compare(a.Location, b.Location)
+	})
+
+	for _, entry := range entries {
+		file, row := entry.Location, int64(0)
+		if i := strings.LastIndex(entry.Location, ":"); i != -1 {
+			if n, err := strconv.ParseInt(entry.Location[i+1:], 10, 64); err == nil {
+				file, row = entry.Location[:i], n
+			}
+		}
+
+		var stack []*profile.Location
+
+		if category, title, ok := strings.Cut(entry.Rule, "/"); ok {
+			stack = []*profile.Location{location(category+"/"+title, file, row), location(category, "", 0)}
+		} else {
+			stack = []*profile.Location{location(file, file, row)}
+		}
+
+		p.Sample = append(p.Sample, &profile.Sample{
+			Location: stack,
+			Value:    []int64{entry.TotalTimeNs, int64(entry.NumEval)},
+		})
+	}
+
+	if err := p.CheckValid(); err != nil {
+		return fmt.Errorf("invalid profile: %w", err)
+	}
+
+	if err := p.Write(w); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package reporter
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+func TestWriteProfile(t *testing.T) {
+	t.Parallel()
+
+	r := report.Report{AggregateProfile: map[string]report.ProfileEntry{
+		"/regal/rules/style/opa-fmt/opa_fmt.rego:10": {
+			Location: "/regal/rules/style/opa-fmt/opa_fmt.rego:10", Rule: "style/opa-fmt", TotalTimeNs: 300, NumEval: 3,
+		},
+		"/regal/ast/ast.rego:20": {
+			Location: "/regal/ast/ast.rego:20", TotalTimeNs: 100, NumEval: 1,
+		},
+	}}
+
+	var buf bytes.Buffer
+	testutil.NoErr(WriteProfile(&buf, r))(t)
+
+	p := testutil.Must(profile.Parse(&buf))(t)
+
+	if len(p.Sample) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(p.Sample))
+	}
+
+	for _, sample := range p.Sample {
+		var frames []string
+		for _, loc := range sample.Location {
+			frames = append(frames, loc.Line[0].Function.Name)
+		}
+
+		switch sample.Value[0] {
+		case 300:
+			if !slices.Equal(frames, []string{"style/opa-fmt", "style"}) || sample.Location[0].Line[0].Line != 10 {
+				t.Errorf("expected rule frame on line 10 above category frame, got %v", frames)
+			}
+
+			if sample.Value[1] != 3 {
+				t.Errorf("expected 3 evals, got %d", sample.Value[1])
+			}
+		case 100:
+			if !slices.Equal(frames, []string{"/regal/ast/ast.rego"}) {
+				t.Errorf("expected single frame named after file, got %v", frames)
+			}
+		default:
+			t.Errorf("unexpected sample value %v", sample.Value)
+		}
+	}
+}
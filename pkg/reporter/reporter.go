@@ -1,11 +1,20 @@
 package reporter
 
 import (
+	"cmp"
 	"context"
+	"encoding/xml"
 	"fmt"
 	"io"
+	"log"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/jstemmer/go-junit-report/v2/junit"
@@ -65,6 +74,73 @@ type JUnitReporter struct {
 	out io.Writer
 }
 
+// CheckstyleReporter reports violations in the Checkstyle XML format, grouped by file.
+type CheckstyleReporter struct {
+	out io.Writer
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// GitLabReporter reports violations in the GitLab Code Quality report format
+// (https://docs.gitlab.com/ci/testing/code_quality/#code-quality-report-format).
+type GitLabReporter struct {
+	out        io.Writer
+	configFile string
+}
+
+type gitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitLabLocation `json:"location"`
+}
+
+type gitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitLabLines `json:"lines"`
+}
+
+type gitLabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// NDJSONReporter reports violations as newline delimited JSON (https://github.com/ndjson/ndjson-spec),
+// with one line per violation, followed by one line per notice and finally a line with the summary.
+// Each line is an object with a "type" attribute of either "violation", "notice" or "summary", and
+// an attribute by the same name holding the data. Violations may be published while linting is still
+// in progress by calling PublishViolations, in which case Publish only reports notices and the summary.
+type NDJSONReporter struct {
+	out      io.Writer
+	mu       *sync.Mutex
+	streamed *bool
+}
+
+type ndjsonLine struct {
+	Type      string            `json:"type"`
+	Violation *report.Violation `json:"violation,omitempty"`
+	Notice    *report.Notice    `json:"notice,omitempty"`
+	Summary   *report.Summary   `json:"summary,omitempty"`
+}
+
 // NewPrettyReporter creates a new PrettyReporter.
 func NewPrettyReporter(out io.Writer) PrettyReporter {
 	return PrettyReporter{out: out}
@@ -95,16 +171,40 @@ func NewSarifReporter(out io.Writer) SarifReporter {
 	return SarifReporter{out: out}
 }
 
+// NewNDJSONReporter creates a new NDJSONReporter.
+func NewNDJSONReporter(out io.Writer) NDJSONReporter {
+	return NDJSONReporter{out: out, mu: &sync.Mutex{}, streamed: new(bool)}
+}
+
 // NewJUnitReporter creates a new JUnitReporter.
 func NewJUnitReporter(out io.Writer) JUnitReporter {
 	return JUnitReporter{out: out}
 }
 
+// NewGitLabReporter creates a new GitLabReporter.
+func NewGitLabReporter(out io.Writer) GitLabReporter {
+	return GitLabReporter{out: out}
+}
+
+// WithConfigFile sets the path of the configuration file to report violations without a location
+// on, like those from aggregate rules, as GitLab requires a path for each issue. If not set, such
+// violations are left out of the report, with a notice logged.
+func (tr GitLabReporter) WithConfigFile(path string) GitLabReporter {
+	tr.configFile = path
+
+	return tr
+}
+
+// NewCheckstyleReporter creates a new CheckstyleReporter.
+func NewCheckstyleReporter(out io.Writer) CheckstyleReporter {
+	return CheckstyleReporter{out: out}
+}
+
 // Publish prints a pretty report to the configured output.
 func (tr PrettyReporter) Publish(_ context.Context, r report.Report) error {
 	table := buildPrettyViolationsTable(r.Violations)
 
-	numsWarning, numsError := 0, 0
+	numsWarning, numsError, numsInfo, numsHint := 0, 0, 0, 0
 
 	for i := range r.Violations {
 		switch r.Violations[i].Level {
@@ -112,6 +212,10 @@ func (tr PrettyReporter) Publish(_ context.Context, r report.Report) error {
 			numsWarning++
 		case "error":
 			numsError++
+		case "info":
+			numsInfo++
+		case "hint":
+			numsHint++
 		}
 	}
 
@@ -122,10 +226,21 @@ func (tr PrettyReporter) Publish(_ context.Context, r report.Report) error {
 	} else {
 		footer += fmt.Sprintf(" %d %s ", r.Summary.NumViolations, pluralize("violation", r.Summary.NumViolations))
 
-		if numsWarning > 0 {
-			footer += fmt.Sprintf("(%d %s, %d %s) found",
-				numsError, pluralize("error", numsError), numsWarning, pluralize("warning", numsWarning),
-			)
+		if numsWarning+numsInfo+numsHint > 0 {
+			counts := []string{
+				fmt.Sprintf("%d %s", numsError, pluralize("error", numsError)),
+				fmt.Sprintf("%d %s", numsWarning, pluralize("warning", numsWarning)),
+			}
+
+			if numsInfo > 0 {
+				counts = append(counts, fmt.Sprintf("%d info", numsInfo))
+			}
+
+			if numsHint > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", numsHint, pluralize("hint", numsHint)))
+			}
+
+			footer += "(" + strings.Join(counts, ", ") + ") found"
 		} else {
 			footer += "found"
 		}
@@ -137,6 +252,22 @@ func (tr PrettyReporter) Publish(_ context.Context, r report.Report) error {
 		}
 	}
 
+	if r.Summary.BaselineMatched > 0 || r.Summary.BaselineStale > 0 {
+		staleEntries := "entries"
+		if r.Summary.BaselineStale == 1 {
+			staleEntries = "entry"
+		}
+
+		footer += fmt.Sprintf(" %d baselined %s suppressed, %d baseline %s no longer found.",
+			r.Summary.BaselineMatched, pluralize("violation", r.Summary.BaselineMatched),
+			r.Summary.BaselineStale, staleEntries,
+		)
+	}
+
+	if len(r.Thresholds) > 0 {
+		footer += " Warning thresholds: " + thresholdsSummary(r.Thresholds) + "."
+	}
+
 	if r.Summary.RulesSkipped > 0 {
 		footer += fmt.Sprintf(" %d %s skipped:\n", r.Summary.RulesSkipped, pluralize("rule", r.Summary.RulesSkipped))
 		sb := &strings.Builder{}
@@ -150,6 +281,10 @@ func (tr PrettyReporter) Publish(_ context.Context, r report.Report) error {
 		footer += sb.String()
 	}
 
+	if len(r.RuleProfile) > 0 {
+		footer += "\n" + buildPrettyRuleProfileTable(r.RuleProfile, r.CategoryProfile)
+	}
+
 	_, err := fmt.Fprintln(tr.out, table+footer)
 	if err != nil {
 		return fmt.Errorf("failed to write report: %w", err)
@@ -218,13 +353,20 @@ func buildPrettyViolationsTable(violations []report.Violation) string {
 	yellow := color.New(color.FgYellow).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
 	red := color.New(color.FgRed).SprintFunc()
+	blue := color.New(color.FgBlue).SprintFunc()
 
 	// rangeValCopy, but Not performance sensitive
 	//nolint:gocritic
 	for i, violation := range violations {
-		description := red(violation.Description)
-		if violation.Level == "warning" {
+		var description string
+
+		switch violation.Level {
+		case "warning":
 			description = yellow(violation.Description)
+		case "info", "hint":
+			description = blue(violation.Description)
+		default:
+			description = red(violation.Description)
 		}
 
 		table.Append([]string{yellow("Rule:"), violation.Title})
@@ -266,6 +408,40 @@ func buildPrettyViolationsTable(violations []report.Violation) string {
 	return sb.String() + end
 }
 
+// buildPrettyRuleProfileTable renders the 10 most expensive rules, followed by all categories,
+// by the time spent evaluating them. The full list of rules is available in the JSON report.
+func buildPrettyRuleProfileTable(rules, categories []report.RuleProfileEntry) string {
+	sb := &strings.Builder{}
+	table := tablewriter.NewTable(sb, tablewriter.WithConfig(tablewriter.Config{
+		Row: tw.CellConfig{
+			Alignment: tw.CellAlignment{PerColumn: []tw.Align{tw.AlignLeft, tw.AlignRight, tw.AlignRight, tw.AlignRight}},
+		},
+	}))
+
+	table.Header([]string{"Rule", "Time", "Evals", "Violations"})
+
+	for _, entry := range rules[:min(10, len(rules))] {
+		table.Append(ruleProfileRow(entry.Category+"/"+entry.Title, entry))
+	}
+
+	for _, entry := range categories {
+		table.Append(ruleProfileRow(entry.Category+" (category)", entry))
+	}
+
+	table.Render()
+
+	return sb.String()
+}
+
+func ruleProfileRow(name string, entry report.RuleProfileEntry) []string {
+	return []string{
+		name,
+		time.Duration(entry.TotalTimeNs).Round(time.Microsecond).String(),
+		strconv.Itoa(entry.NumEval),
+		strconv.Itoa(entry.NumViolations),
+	}
+}
+
 // Publish prints a compact report to the configured output.
 func (tr CompactReporter) Publish(_ context.Context, r report.Report) error {
 	if len(r.Violations) == 0 {
@@ -310,6 +486,49 @@ func (tr JSONReporter) Publish(_ context.Context, r report.Report) error {
 	return enc.Encode(r)
 }
 
+// PublishViolations prints violations to the configured output as soon as they're found.
+func (tr NDJSONReporter) PublishViolations(violations []report.Violation) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	*tr.streamed = true
+
+	for i := range violations {
+		if err := tr.writeLine(ndjsonLine{Type: "violation", Violation: &violations[i]}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Publish prints a newline delimited JSON report to the configured output. Violations are
+// omitted if they have already been published using PublishViolations.
+func (tr NDJSONReporter) Publish(_ context.Context, r report.Report) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if !*tr.streamed {
+		for i := range r.Violations {
+			if err := tr.writeLine(ndjsonLine{Type: "violation", Violation: &r.Violations[i]}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range r.Notices {
+		if err := tr.writeLine(ndjsonLine{Type: "notice", Notice: &r.Notices[i]}); err != nil {
+			return err
+		}
+	}
+
+	return tr.writeLine(ndjsonLine{Type: "summary", Summary: &r.Summary})
+}
+
+func (tr NDJSONReporter) writeLine(line ndjsonLine) error {
+	return encoding.JSON().NewEncoder(tr.out).Encode(line)
+}
+
 // Publish first prints the pretty formatted report to console for easy access in the logs. It then goes on
 // to print the GitHub Actions annotations for each violation. Finally, it prints a summary of the report suitable
 // for the GitHub Actions UI.
@@ -323,7 +542,7 @@ func (tr GitHubReporter) Publish(ctx context.Context, r report.Report) error {
 	for _, violation := range r.Violations { //nolint:gocritic
 		if _, err := fmt.Fprintf(tr.out,
 			"::%s file=%s,line=%d,col=%d::%s\n",
-			violation.Level,
+			gitHubLevel(violation.Level),
 			violation.Location.File,
 			violation.Location.Row,
 			violation.Location.Column,
@@ -381,6 +600,10 @@ func (tr SarifReporter) Publish(_ context.Context, r report.Report) error {
 		pb := sarif.NewPropertyBag()
 		pb.Add("category", violation.Category)
 
+		if len(violation.Tags) > 0 {
+			pb.Add("tags", violation.Tags)
+		}
+
 		run.AddRule(violation.Title).
 			WithDescription(violation.Description).
 			WithHelpURI(getDocumentationURL(violation)).
@@ -389,7 +612,7 @@ func (tr SarifReporter) Publish(_ context.Context, r report.Report) error {
 		run.AddDistinctArtifact(violation.Location.File)
 
 		run.CreateResultForRule(violation.Title).
-			WithLevel(violation.Level).
+			WithLevel(sarifLevel(violation.Level)).
 			WithMessage(sarif.NewTextMessage(violation.Description)).
 			AddLocation(getLocation(violation))
 	}
@@ -419,6 +642,94 @@ func (tr SarifReporter) Publish(_ context.Context, r report.Report) error {
 	return rep.PrettyWrite(tr.out)
 }
 
+// Publish prints a GitLab Code Quality report to the configured output. Fingerprints are based on
+// the fingerprint of each violation, with identical violations in the same file told apart by the
+// order in which they occur, as GitLab requires each issue to have a unique fingerprint.
+func (tr GitLabReporter) Publish(_ context.Context, r report.Report) error {
+	issues := make([]gitLabIssue, 0, len(r.Violations))
+	occurrences := make(map[string]int, len(r.Violations))
+	skipped := 0
+
+	for _, violation := range r.Violations { //nolint:gocritic
+		path := cmp.Or(violation.Location.File, tr.configFile)
+		if path == "" {
+			skipped++
+
+			continue
+		}
+
+		fingerprint := violation.Fingerprint()
+		if n := occurrences[fingerprint]; n > 0 {
+			occurrences[fingerprint]++
+			fingerprint += "-" + strconv.Itoa(n)
+		} else {
+			occurrences[fingerprint] = 1
+		}
+
+		// lines are 1-based, and violations without a location are reported on the first line
+		begin := max(violation.Location.Row, 1)
+		end := begin
+
+		if violation.Location.End != nil {
+			end = max(violation.Location.End.Row, begin)
+		}
+
+		issues = append(issues, gitLabIssue{
+			Description: violation.Description,
+			CheckName:   violation.Category + "/" + violation.Title,
+			Fingerprint: fingerprint,
+			Severity:    gitLabSeverity(violation.Level),
+			Location: gitLabLocation{
+				Path:  filepath.ToSlash(path),
+				Lines: gitLabLines{Begin: begin, End: end},
+			},
+		})
+	}
+
+	if skipped > 0 {
+		log.Printf("left %d violation(s) without a location out of the GitLab report, "+
+			"as no configuration file was found to report them on", skipped)
+	}
+
+	enc := encoding.JSON().NewEncoder(tr.out)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(issues)
+}
+
+// gitLabSeverity returns the Code Quality severity for a violation level, out of the
+// info, minor, major, critical and blocker severities supported by GitLab.
+func gitLabSeverity(level string) string {
+	switch level {
+	case "error":
+		return "major"
+	case "warning":
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+// gitHubLevel returns the GitHub Actions annotation command for a violation level,
+// as GitHub only has error, warning and notice annotations.
+func gitHubLevel(level string) string {
+	if level == "info" || level == "hint" {
+		return "notice"
+	}
+
+	return level
+}
+
+// sarifLevel returns the SARIF result level for a violation level, as SARIF only
+// has error, warning, note and none levels.
+func sarifLevel(level string) string {
+	if level == "info" || level == "hint" {
+		return "note"
+	}
+
+	return level
+}
+
 func getLocation(violation report.Violation) *sarif.Location {
 	physicalLocation := sarif.NewPhysicalLocation().
 		WithArtifactLocation(sarif.NewSimpleArtifactLocation(violation.Location.File))
@@ -499,6 +810,85 @@ func (tr JUnitReporter) Publish(_ context.Context, r report.Report) error {
 	return testSuites.WriteXML(tr.out)
 }
 
+// Publish prints a Checkstyle XML report to the configured output.
+func (tr CheckstyleReporter) Publish(_ context.Context, r report.Report) error {
+	violationsPerFile := map[string][]checkstyleError{}
+
+	for _, violation := range r.Violations { //nolint:gocritic
+		violationsPerFile[violation.Location.File] = append(violationsPerFile[violation.Location.File], checkstyleError{
+			Line:     violation.Location.Row,
+			Column:   violation.Location.Column,
+			Severity: checkstyleSeverity(violation.Level),
+			Message:  violation.Description,
+			Source:   "regal." + violation.Category + "." + violation.Title,
+		})
+	}
+
+	files := make([]checkstyleFile, 0, len(violationsPerFile))
+	for _, file := range slices.Sorted(maps.Keys(violationsPerFile)) {
+		files = append(files, checkstyleFile{Name: file, Errors: violationsPerFile[file]})
+	}
+
+	return writeCheckstyle(tr.out, files)
+}
+
+// WriteCheckstyleError writes a Checkstyle XML report with err as its only error, for
+// tools expecting Checkstyle output to be told about failures to lint.
+func WriteCheckstyleError(w io.Writer, err error) error {
+	return writeCheckstyle(w, []checkstyleFile{{
+		Errors: []checkstyleError{{Severity: "error", Message: err.Error(), Source: "regal"}},
+	}})
+}
+
+func writeCheckstyle(w io.Writer, files []checkstyleFile) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+
+	if err := enc.Encode(checkstyleReport{Version: "4.3", Files: files}); err != nil {
+		return fmt.Errorf("failed to encode checkstyle report: %w", err)
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+// checkstyleSeverity returns the Checkstyle severity for a violation level, as Checkstyle
+// only has error, warning, info and ignore severities.
+func checkstyleSeverity(level string) string {
+	if level == "hint" {
+		return "info"
+	}
+
+	return level
+}
+
+// thresholdsSummary shows the number of warnings found against the maximum allowed for each
+// threshold, like "12/50 in total, 11/10 in style (exceeded)".
+func thresholdsSummary(thresholds []report.Threshold) string {
+	summaries := make([]string, 0, len(thresholds))
+
+	for _, threshold := range thresholds {
+		scope := "in total"
+		if threshold.Category != "" {
+			scope = "in " + threshold.Category
+		}
+
+		summary := fmt.Sprintf("%d/%d %s", threshold.Warnings, threshold.MaxWarnings, scope)
+		if threshold.Exceeded() {
+			summary += " (exceeded)"
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return strings.Join(summaries, ", ")
+}
+
 func pluralize(singular string, count int) string {
 	if count == 1 {
 		return singular
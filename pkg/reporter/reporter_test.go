@@ -2,6 +2,7 @@ package reporter
 
 import (
 	"bytes"
+	"errors"
 	"strings"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/open-policy-agent/regal/internal/testutil"
 	"github.com/open-policy-agent/regal/internal/util"
 	"github.com/open-policy-agent/regal/pkg/report"
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
 )
 
 var rep = report.Report{
@@ -67,6 +69,26 @@ var rep = report.Report{
 	},
 }
 
+var infoAndHintReport = report.Report{
+	Summary: report.Summary{FilesScanned: 1, NumViolations: 2, FilesFailed: 1},
+	Violations: []report.Violation{
+		{
+			Title:       "informative",
+			Description: "Info found",
+			Category:    "style",
+			Location:    report.Location{File: "a.rego", Row: 1, Column: 1},
+			Level:       "info",
+		},
+		{
+			Title:       "hinting",
+			Description: "Hint found",
+			Category:    "style",
+			Location:    report.Location{File: "a.rego", Row: 2, Column: 1},
+			Level:       "hint",
+		},
+	},
+}
+
 func TestPrettyReporterPublish(t *testing.T) {
 	t.Parallel()
 
@@ -127,6 +149,37 @@ func TestPrettyReporterPublishNoViolations(t *testing.T) {
 	}
 }
 
+func TestPrettyReporterPublishInfoAndHint(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewPrettyReporter(&buf).Publish(t.Context(), infoAndHintReport))(t)
+
+	if expect := "2 violations (0 errors, 0 warnings, 1 info, 1 hint) found."; !strings.Contains(buf.String(), expect) {
+		t.Errorf("expected footer %q, got %q", expect, buf.String())
+	}
+}
+
+func TestPrettyReporterPublishThresholds(t *testing.T) {
+	t.Parallel()
+
+	thresholdsRep := report.Report{
+		Summary: report.Summary{FilesScanned: 1},
+		Thresholds: []report.Threshold{
+			{MaxWarnings: 50, Warnings: 12},
+			{Category: "style", MaxWarnings: 10, Warnings: 11},
+		},
+	}
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewPrettyReporter(&buf).Publish(t.Context(), thresholdsRep))(t)
+
+	expect := "1 file linted. No violations found. Warning thresholds: 12/50 in total, 11/10 in style (exceeded).\n"
+	if buf.String() != expect {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+}
+
 func TestCompactReporterPublish(t *testing.T) {
 	t.Parallel()
 
@@ -180,6 +233,33 @@ func TestJSONReporterPublishNoViolations(t *testing.T) {
 	}
 }
 
+func TestNDJSONReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewNDJSONReporter(&buf).Publish(t.Context(), rep))(t)
+
+	if expect := testutil.MustReadFile(t, "testdata/ndjson/reporter.ndjson"); expect != buf.String() {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+}
+
+func TestNDJSONReporterPublishStreamed(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	r := NewNDJSONReporter(&buf)
+	testutil.NoErr(r.PublishViolations(rep.Violations[:1]))(t)
+	testutil.NoErr(r.PublishViolations(rep.Violations[1:]))(t)
+	testutil.NoErr(r.Publish(t.Context(), rep))(t)
+
+	// violations published while streaming must not be repeated by Publish
+	if expect := testutil.MustReadFile(t, "testdata/ndjson/reporter.ndjson"); expect != buf.String() {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+}
+
 func TestGitHubReporterPublish(t *testing.T) {
 	// Can't use t.Parallel() here because t.Setenv() forbids that
 	t.Setenv("GITHUB_STEP_SUMMARY", "")
@@ -200,6 +280,23 @@ func TestGitHubReporterPublish(t *testing.T) {
 	}
 }
 
+func TestGitHubReporterPublishInfoAndHint(t *testing.T) {
+	// Can't use t.Parallel() here because t.Setenv() forbids that
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewGitHubReporter(&buf).Publish(t.Context(), infoAndHintReport))(t)
+
+	for _, expect := range []string{
+		"::notice file=a.rego,line=1,col=1::Info found. To learn more, see: ",
+		"::notice file=a.rego,line=2,col=1::Hint found. To learn more, see: ",
+	} {
+		if !strings.Contains(buf.String(), expect) {
+			t.Errorf("expected workflow command output %q, got %q", expect, buf.String())
+		}
+	}
+}
+
 func TestGitHubReporterPublishNoViolations(t *testing.T) {
 	// Can't use t.Parallel() here because t.Setenv() forbids that
 	t.Setenv("GITHUB_STEP_SUMMARY", "")
@@ -214,6 +311,106 @@ func TestGitHubReporterPublishNoViolations(t *testing.T) {
 	}
 }
 
+func TestGitLabReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewGitLabReporter(&buf).Publish(t.Context(), rep))(t)
+
+	if expect := testutil.MustReadFile(t, "testdata/gitlab/reporter.json"); buf.String() != expect {
+		t.Errorf("expected %s, got %s", expect, buf.String())
+	}
+}
+
+func TestGitLabReporterPublishNoViolations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewGitLabReporter(&buf).Publish(t.Context(), report.Report{}))(t)
+
+	if expect := "[]\n"; buf.String() != expect {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+}
+
+func TestGitLabReporterUniqueFingerprints(t *testing.T) {
+	t.Parallel()
+
+	violation := report.Violation{
+		Title:    "print-or-trace-call",
+		Category: "testing",
+		Level:    "info",
+		Location: report.Location{File: "p.rego", Row: 3, Column: 2, Text: util.Pointer("print(x)")},
+	}
+	duplicate := violation
+	duplicate.Location.Row = 7
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewGitLabReporter(&buf).Publish(t.Context(), report.Report{
+		Violations: []report.Violation{violation, duplicate, duplicate},
+	}))(t)
+
+	issues := testutil.Must(encoding.JSONUnmarshalTo[[]gitLabIssue](buf.Bytes()))(t)
+
+	fingerprint := violation.Fingerprint()
+	if diff := cmp.Diff(
+		[]string{fingerprint, fingerprint + "-1", fingerprint + "-2"},
+		[]string{issues[0].Fingerprint, issues[1].Fingerprint, issues[2].Fingerprint},
+	); diff != "" {
+		t.Errorf("unexpected fingerprints (-want, +got):\n%s", diff)
+	}
+
+	if issues[0].Severity != "info" || issues[1].Location.Lines != (gitLabLines{Begin: 7, End: 7}) {
+		t.Errorf("unexpected issue %+v", issues[1])
+	}
+}
+
+func TestGitLabReporterViolationWithoutLocation(t *testing.T) {
+	t.Parallel()
+
+	violation := report.Violation{
+		Title:       "no-defined-entrypoint",
+		Category:    "idiomatic",
+		Description: "Missing entrypoint annotation",
+		Level:       "error",
+	}
+
+	testCases := map[string]struct {
+		configFile string
+		expected   []gitLabLocation
+	}{
+		"reported on config file": {
+			configFile: ".regal/config.yaml",
+			expected:   []gitLabLocation{{Path: ".regal/config.yaml", Lines: gitLabLines{Begin: 1, End: 1}}},
+		},
+		"left out without config file": {
+			expected: []gitLabLocation{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			testutil.NoErr(NewGitLabReporter(&buf).WithConfigFile(tc.configFile).Publish(t.Context(), report.Report{
+				Violations: []report.Violation{violation},
+			}))(t)
+
+			issues := testutil.Must(encoding.JSONUnmarshalTo[[]gitLabIssue](buf.Bytes()))(t)
+
+			locations := make([]gitLabLocation, 0, len(issues))
+			for _, issue := range issues {
+				locations = append(locations, issue.Location)
+			}
+
+			if diff := cmp.Diff(tc.expected, locations); diff != "" {
+				t.Errorf("unexpected locations (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSarifReporterPublish(t *testing.T) {
 	t.Parallel()
 
@@ -260,6 +457,17 @@ func TestSarifReporterPublishNoViolations(t *testing.T) {
 	}
 }
 
+func TestSarifReporterPublishInfoAndHint(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewSarifReporter(&buf).Publish(t.Context(), infoAndHintReport))(t)
+
+	if count := strings.Count(buf.String(), `"level": "note"`); count != 2 {
+		t.Errorf("expected 2 results with level note, got %d in %s", count, buf.String())
+	}
+}
+
 func TestJUnitReporterPublish(t *testing.T) {
 	t.Parallel()
 
@@ -290,3 +498,54 @@ func TestJUnitReporterPublishViolationWithoutText(t *testing.T) {
 		Violations: []report.Violation{{Title: "no-text"}},
 	}))(t)
 }
+
+func TestCheckstyleReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewCheckstyleReporter(&buf).Publish(t.Context(), rep))(t)
+
+	if expect := testutil.MustReadFile(t, "testdata/checkstyle/reporter.xml"); buf.String() != expect {
+		t.Errorf("expected \n%s, got \n%s", expect, buf.String())
+	}
+}
+
+func TestCheckstyleReporterPublishNoViolations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewCheckstyleReporter(&buf).Publish(t.Context(), report.Report{}))(t)
+
+	if expect := testutil.MustReadFile(t, "testdata/checkstyle/reporter-no-violations.xml"); buf.String() != expect {
+		t.Errorf("expected \n%s, got \n%s", expect, buf.String())
+	}
+}
+
+func TestCheckstyleReporterPublishInfoAndHint(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(NewCheckstyleReporter(&buf).Publish(t.Context(), infoAndHintReport))(t)
+
+	if strings.Count(buf.String(), `severity="info"`) != 2 {
+		t.Errorf("expected both info and hint to be reported as severity info, got \n%s", buf.String())
+	}
+}
+
+func TestWriteCheckstyleError(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	testutil.NoErr(WriteCheckstyleError(&buf, errors.New("failed to <lint>")))(t)
+
+	expect := `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+	<file name="">
+		<error severity="error" message="failed to &lt;lint&gt;" source="regal"></error>
+	</file>
+</checkstyle>
+`
+	if buf.String() != expect {
+		t.Errorf("expected \n%s, got \n%s", expect, buf.String())
+	}
+}
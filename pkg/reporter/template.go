@@ -0,0 +1,79 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/fatih/color"
+
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// TemplateReporter reports violations using a user-provided Go text/template, which is
+// executed with the report.Report as its data. Besides the functions built into the
+// template package, templates may use the functions returned by TemplateFuncs.
+type TemplateReporter struct {
+	out  io.Writer
+	tmpl *template.Template
+}
+
+// NewTemplateReporter creates a new TemplateReporter from the source of a template.
+func NewTemplateReporter(out io.Writer, source string) (TemplateReporter, error) {
+	tmpl, err := template.New("report").Funcs(TemplateFuncs()).Parse(source)
+	if err != nil {
+		return TemplateReporter{}, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	return TemplateReporter{out: out, tmpl: tmpl}, nil
+}
+
+// TemplateFuncs returns the helper functions available to templates of the TemplateReporter:
+//
+//   - relpath returns a path relative to the current working directory, when possible
+//   - pluralize returns a word, with an "s" appended unless the count is 1
+//   - red, green, yellow, blue, magenta, cyan and bold color text, unless color.NoColor is set
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"relpath":   relativePath,
+		"pluralize": pluralize,
+		"red":       color.New(color.FgRed).Sprint,
+		"green":     color.New(color.FgGreen).Sprint,
+		"yellow":    color.New(color.FgYellow).Sprint,
+		"blue":      color.New(color.FgBlue).Sprint,
+		"magenta":   color.New(color.FgMagenta).Sprint,
+		"cyan":      color.New(color.FgCyan).Sprint,
+		"bold":      color.New(color.Bold).Sprint,
+	}
+}
+
+// Publish executes the template with the report, and prints the result to the configured output.
+func (tr TemplateReporter) Publish(_ context.Context, r report.Report) error {
+	if err := tr.tmpl.Execute(tr.out, r); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return nil
+}
+
+func relativePath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
@@ -0,0 +1,62 @@
+package reporter
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+func TestTemplateReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	source := `{{ range .Violations -}}
+{{ relpath .Location.File }}:{{ .Location.Row }} {{ .Category }}/{{ .Title }} ({{ .Level }})
+{{ end -}}
+{{ .Summary.NumViolations }} {{ pluralize "violation" .Summary.NumViolations }}, ` +
+		`{{ len .Notices }} {{ pluralize "notice" (len .Notices) }}
+`
+
+	var buf bytes.Buffer
+
+	tr := testutil.Must(NewTemplateReporter(&buf, source))(t)
+	testutil.NoErr(tr.Publish(t.Context(), rep))(t)
+
+	expect := `a.rego:1 legal/breaking-the-law (error)
+b.rego:22 really?/questionable-decision (warning)
+2 violations, 2 notices
+`
+	if buf.String() != expect {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+}
+
+func TestTemplateReporterParseError(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewTemplateReporter(nil, "{{ .Violations"); err == nil {
+		t.Error("expected error parsing template")
+	}
+}
+
+func TestTemplateReporterExecuteError(t *testing.T) {
+	t.Parallel()
+
+	tr := testutil.Must(NewTemplateReporter(&bytes.Buffer{}, "{{ .Unknown }}"))(t)
+
+	if err := tr.Publish(t.Context(), report.Report{}); err == nil {
+		t.Error("expected error executing template")
+	}
+}
+
+func TestRelativePath(t *testing.T) {
+	t.Parallel()
+
+	abs := testutil.Must(filepath.Abs(filepath.Join("testdata", "p.rego")))(t)
+
+	if got, expect := relativePath(abs), filepath.Join("testdata", "p.rego"); got != expect {
+		t.Errorf("expected %q, got %q", expect, got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -23,6 +24,9 @@ type Input struct {
 	Modules map[string]*ast.Module
 	// FileNames is used to maintain consistent order between runs.
 	FileNames []string
+	// load reads and parses files not found in FileContent and Modules, when the
+	// input was created with LazyInputFromPaths.
+	load func(name string) (*regoFile, error)
 }
 
 type regoFile struct {
@@ -90,6 +94,51 @@ func InputFromPaths(paths []string, prefix string, versionsMap map[string]ast.Re
 	return NewInput(content, modules), nil
 }
 
+// LazyInputFromPaths creates a new Input from a set of file paths, like InputFromPaths, but where
+// files are only read and parsed when requested by a call to File. This allows linting a large number
+// of files without holding the contents of all of them in memory at once. The same assumptions about
+// the paths as for InputFromPaths apply, but note that reading from stdin is not supported.
+func LazyInputFromPaths(paths []string, prefix string, versionsMap map[string]ast.RegoVersion) Input {
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		names = append(names, filepath.Clean(path))
+	}
+
+	slices.Sort(names)
+
+	return Input{
+		FileContent: make(map[string]string),
+		Modules:     make(map[string]*ast.Module),
+		FileNames:   slices.Compact(names),
+		load: func(name string) (*regoFile, error) {
+			opts := parse.ParserOptions()
+			opts.RegoVersion = RegoVersionFromMap(versionsMap, strings.TrimPrefix(name, prefix), ast.RegoUndefined)
+
+			return regoWithOpts(name, opts)
+		},
+	}
+}
+
+// File returns the contents and the parsed module of the named file. For inputs created with
+// LazyInputFromPaths, files not already present in the input are read and parsed on each call,
+// and are not retained in the input.
+func (in Input) File(name string) (string, *ast.Module, error) {
+	if module, ok := in.Modules[name]; ok {
+		return in.FileContent[name], module, nil
+	}
+
+	if in.load == nil {
+		return "", nil, fmt.Errorf("file %s not found in input", name)
+	}
+
+	file, err := in.load(name)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse module %s: %w", name, err)
+	}
+
+	return util.ByteSliceToString(file.raw), file.parsed, nil
+}
+
 // InputFromMap creates a new Input from a map of file paths to their contents.
 // This function uses a vesrionsMap to determine the parser version for each
 // file before parsing the module.
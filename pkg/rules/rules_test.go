@@ -7,6 +7,7 @@ import (
 	"github.com/open-policy-agent/opa/v1/ast"
 
 	"github.com/open-policy-agent/regal/internal/parse"
+	"github.com/open-policy-agent/regal/internal/testutil"
 	"github.com/open-policy-agent/regal/pkg/rules"
 )
 
@@ -158,3 +159,40 @@ allow[msg] { msg := "hello" }
 		t.Fatalf("Expected 2 modules, got %d", len(input.Modules))
 	}
 }
+
+func TestLazyInputFromPaths(t *testing.T) {
+	t.Parallel()
+
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"v1/main.rego": "package main\n\nallow if input.admin\n",
+		"v0/main.rego": "package main\n\nallow { input.admin }\n",
+	})
+
+	versionsMap := map[string]ast.RegoVersion{"v1": ast.RegoV1, "v0": ast.RegoV0}
+	paths := []string{filepath.Join(root, "v1", "main.rego"), filepath.Join(root, "v0", "main.rego")}
+
+	input := rules.LazyInputFromPaths(paths, root, versionsMap)
+
+	if len(input.FileNames) != 2 || len(input.Modules) != 0 || len(input.FileContent) != 0 {
+		t.Fatalf("expected 2 file names and no files loaded, got %v", input)
+	}
+
+	for _, name := range input.FileNames {
+		content, module, err := input.File(name)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if content == "" || module == nil {
+			t.Errorf("expected content and module for %s", name)
+		}
+	}
+
+	if len(input.Modules) != 0 {
+		t.Errorf("expected loaded files not to be retained, got %d modules", len(input.Modules))
+	}
+
+	if _, _, err := input.File(filepath.Join(root, "missing.rego")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}